@@ -0,0 +1,79 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	u "net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BugReportURL returns a URL for creating a new issue, with the body prefilled
+// with the version, SHA, platform, Go version, and build settings of the
+// application, followed by the given extra values, sorted by key. Returns nil
+// if the source URL is not set, or is not hosted on a service that supports
+// prefilled issues. GitHub, GitLab, and Gitea are supported, including
+// self-hosted instances.
+func BugReportURL(extra map[string]string) *u.URL {
+	return bugReportURL(Source(), bugReportBody(BuildSettings(), extra))
+}
+
+// bugReportURL returns a URL for creating a new issue with the given body, for
+// the repository with the given URL.
+func bugReportURL(repo *u.URL, body string) *u.URL {
+	result := forgeURL(repo, "newIssue")
+	if result == nil {
+		return nil
+	}
+
+	query := u.Values{}
+	query.Set(forges[forgeName(repo.Hostname())].newIssueBody, body)
+	result.RawQuery = query.Encode()
+
+	return result
+}
+
+// bugReportBody returns the body of a bug report, listing the metadata of the
+// application, the given build settings, and the given extra values. Empty
+// values are omitted.
+func bugReportBody(settings Settings, extra map[string]string) string {
+	var b strings.Builder
+
+	line := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", key, value)
+		}
+	}
+
+	line("Version", Version())
+	line("SHA", ShortSHA())
+	line("OS", OS())
+	line("Arch", Arch())
+	line("Go", Go())
+
+	// Build settings are only known if the build information is available.
+	if settings.Compiler != "" {
+		line("Compiler", settings.Compiler)
+		line("Tags", strings.Join(settings.Tags, ","))
+		line("CGO", strconv.FormatBool(settings.CGO))
+		line("Race", strconv.FormatBool(settings.Race))
+		line("Trimpath", strconv.FormatBool(settings.Trimpath))
+	}
+
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		line(key, extra[key])
+	}
+
+	return b.String()
+}
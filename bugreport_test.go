@@ -0,0 +1,81 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	u "net/url"
+	"runtime"
+	"testing"
+)
+
+func TestBugReportURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		repo     string
+		expected string
+	}{
+		{
+			repo:     "https://github.com/joshdk/meta.git",
+			expected: "https://github.com/joshdk/meta/issues/new?body=OS%3A+linux%0A",
+		},
+		{
+			repo:     "https://gitea.example.com/joshdk/meta",
+			expected: "https://gitea.example.com/joshdk/meta/issues/new?body=OS%3A+linux%0A",
+		},
+		{
+			repo:     "https://gitlab.com/joshdk/group/meta",
+			expected: "https://gitlab.com/joshdk/group/meta/-/issues/new?issue%5Bdescription%5D=OS%3A+linux%0A",
+		},
+		{
+			repo: "https://bitbucket.org/joshdk/meta",
+		},
+		{
+			repo: "https://example.com/demo.git",
+		},
+	}
+
+	for _, test := range tests {
+		repo, err := u.Parse(test.repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := bugReportURL(repo, "OS: linux\n")
+
+		switch {
+		case test.expected == "" && actual != nil:
+			t.Fatalf("expected no url for %s but got %s", test.repo, actual)
+		case test.expected != "" && actual == nil:
+			t.Fatalf("expected url for %s but got none", test.repo)
+		case actual != nil:
+			equalString(t, test.expected, actual.String())
+		}
+	}
+
+	// The test binary is built without a source url.
+	if actual := BugReportURL(nil); actual != nil {
+		t.Fatalf("expected no url but got %s", actual)
+	}
+}
+
+func TestBugReportBody(t *testing.T) {
+	t.Parallel()
+
+	platform := "OS: " + runtime.GOOS + "\nArch: " + runtime.GOARCH + "\nGo: " + runtime.Version() + "\n"
+
+	// Build settings are omitted if they are not known.
+	equalString(t, platform+"Command: demo run\n", bugReportBody(Settings{}, map[string]string{
+		"Command": "demo run",
+		"Ignored": "",
+	}))
+
+	settings := Settings{Compiler: "gc", Tags: []string{"netgo", "osusergo"}, CGO: true}
+	expected := platform +
+		"Compiler: gc\nTags: netgo,osusergo\nCGO: true\nRace: false\nTrimpath: false\n" +
+		"A: first\nB: second\n"
+
+	equalString(t, expected, bugReportBody(settings, map[string]string{"B": "second", "A": "first"}))
+}
@@ -0,0 +1,116 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"regexp"
+	"runtime/debug"
+	"strings"
+)
+
+// develVersion is the main module version reported by the Go toolchain when
+// the application was not built from a versioned module.
+const develVersion = "(devel)"
+
+// majorVersionRegex matches a major version suffix element of a module path,
+// such as v2.
+var majorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+// buildInfo is the build information embedded by the Go toolchain, or nil if
+// it is not available.
+var buildInfo *debug.BuildInfo
+
+// inferFallbacks sets the fallback value of any field that can be inferred
+// from the build information embedded by the Go toolchain.
+func inferFallbacks(info *debug.BuildInfo) {
+	for name, value := range inferValues(info) {
+		lookupField(name).fallback = value
+	}
+}
+
+// inferValues returns the values that can be inferred from the given build
+// information, keyed by variable name. Values are only returned if they are
+// valid, since they are not under the control of the user. The SHA and date
+// are not inferred if the working tree had uncommitted changes.
+func inferValues(info *debug.BuildInfo) map[string]string {
+	source := moduleSource(info.Main.Path)
+
+	candidates := map[string]string{
+		"jdk.sh/meta.date":    setting(info, "vcs.time"),
+		"jdk.sh/meta.sha":     setting(info, "vcs.revision"),
+		"jdk.sh/meta.src":     source,
+		"jdk.sh/meta.url":     source,
+		"jdk.sh/meta.version": info.Main.Version,
+	}
+
+	// A modified working tree was not built from the recorded revision, so
+	// neither the revision, nor its commit time, describe the build.
+	if setting(info, "vcs.modified") == "true" {
+		delete(candidates, "jdk.sh/meta.date")
+		delete(candidates, "jdk.sh/meta.sha")
+	}
+
+	values := make(map[string]string)
+
+	for name, value := range candidates {
+		if value == "" || value == develVersion {
+			continue
+		}
+
+		if _, err := lookupField(name).tryParse(value); err == nil {
+			values[name] = value
+		}
+	}
+
+	return values
+}
+
+// setting returns the value of the named build setting, or an empty string if
+// the setting is not present.
+func setting(info *debug.BuildInfo, key string) string {
+	for _, s := range info.Settings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+
+	return ""
+}
+
+// moduleSource returns the URL of the repository containing the given module,
+// or an empty string if it can not be determined. Modules hosted on GitHub,
+// GitLab, or Bitbucket are resolved directly, and vanity import paths are
+// resolved using the mappings added with RegisterVanity.
+func moduleSource(path string) string {
+	elements := strings.Split(path, "/")
+
+	// Any major version suffix, such as v2, is not part of the repository.
+	if n := len(elements); n > 1 && majorVersionRegex.MatchString(elements[n-1]) {
+		elements = elements[:n-1]
+	}
+
+	switch elements[0] {
+	case "github.com", "bitbucket.org":
+		// Repositories are always named by an owner and a repository, and
+		// any further elements are directories within the repository.
+		if len(elements) < 3 { // nolint:gomnd
+			return ""
+		}
+
+		return "https://" + strings.Join(elements[:3], "/")
+
+	case "gitlab.com":
+		// Repositories can be nested in subgroups, so every element is
+		// assumed to be part of the repository.
+		if len(elements) < 3 { // nolint:gomnd
+			return ""
+		}
+
+		return "https://" + strings.Join(elements, "/")
+
+	default:
+		return vanitySource(strings.Join(elements, "/"))
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+// TestInferFallbacks modifies global state, and so must not run in parallel.
+func TestInferFallbacks(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	// Simulate a value that was set using ldflags.
+	sha = "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6"
+
+	inferFallbacks(&debug.BuildInfo{
+		Main: debug.Module{Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123456789abcdef01234567"},
+			{Key: "vcs.time", Value: "2019-08-23T18:00:00Z"},
+		},
+	})
+	parse()
+
+	equalString(t, "v1.2.3", Version())
+	equalString(t, "3", VersionPatch())
+	equalString(t, "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6", SHA())
+	equalString(t, "2019-08-23T18:00:00Z", Data().Date)
+
+	sources := Data().Sources
+	equalString(t, SourceBuildInfo, sources["jdk.sh/meta.version"])
+	equalString(t, SourceBuildInfo, sources["jdk.sh/meta.date"])
+	equalString(t, SourceLdflags, sources["jdk.sh/meta.sha"])
+}
+
+// TestInferFallbacksInvalid modifies global state, and so must not run in
+// parallel.
+func TestInferFallbacksInvalid(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	// Build information that can not be parsed is ignored, rather than
+	// causing a panic.
+	inferFallbacks(&debug.BuildInfo{
+		Main: debug.Module{Version: develVersion},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+		},
+	})
+	parse()
+
+	equalString(t, "", Version())
+	equalString(t, "", SHA())
+
+	if len(Data().Sources) != 0 {
+		t.Fatalf("expected no sources but got %v", Data().Sources)
+	}
+}
+
+func TestModuleSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "", expected: ""},
+		{path: "example", expected: ""},
+		{path: "github.com/joshdk", expected: ""},
+		{path: "github.com/joshdk/meta", expected: "https://github.com/joshdk/meta"},
+		{path: "github.com/joshdk/meta/v2", expected: "https://github.com/joshdk/meta"},
+		{path: "github.com/joshdk/meta/metacobra", expected: "https://github.com/joshdk/meta"},
+		{path: "bitbucket.org/joshdk/meta", expected: "https://bitbucket.org/joshdk/meta"},
+		{path: "gitlab.com/joshdk/group/meta/v3", expected: "https://gitlab.com/joshdk/group/meta"},
+		{path: "example.com/meta", expected: ""},
+	}
+
+	for _, test := range tests {
+		equalString(t, test.expected, moduleSource(test.path))
+	}
+}
+
+// TestRegisterVanity modifies global state, and so must not run in parallel.
+func TestRegisterVanity(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	t.Cleanup(func() {
+		vanities = make(map[string]string)
+	})
+
+	RegisterVanity("example.com", "https://github.com/example")
+	RegisterVanity("example.com/tools/cli", "https://gitlab.com/example/cli")
+
+	equalString(t, "https://github.com/example/meta", moduleSource("example.com/meta/v2"))
+	equalString(t, "https://github.com/example/tools", moduleSource("example.com/tools/lint"))
+	equalString(t, "https://gitlab.com/example/cli", moduleSource("example.com/tools/cli"))
+	equalString(t, "", moduleSource("example.community/meta"))
+
+	inferFallbacks(&debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/meta"},
+	})
+	parse()
+
+	equalString(t, "https://github.com/example/meta", Source().String())
+	equalString(t, "https://github.com/example/meta", URL().String())
+	equalString(t, SourceBuildInfo, Data().Sources["jdk.sh/meta.src"])
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic but got none")
+		}
+	}()
+
+	RegisterVanity("example.org", "git@example.org:repo")
+}
+
+func TestInferValuesModified(t *testing.T) {
+	t.Parallel()
+
+	values := inferValues(&debug.BuildInfo{
+		Main: debug.Module{Version: "v1.2.3-0.20190823180000-0123456789ab+dirty"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123456789abcdef01234567"},
+			{Key: "vcs.time", Value: "2019-08-23T18:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	})
+
+	// The version is still inferred, since it records that the working tree
+	// was modified.
+	equalString(t, "v1.2.3-0.20190823180000-0123456789ab+dirty", values["jdk.sh/meta.version"])
+
+	for _, name := range []string{"jdk.sh/meta.date", "jdk.sh/meta.sha"} {
+		if value, found := values[name]; found {
+			t.Fatalf("expected no value for %s but got %q", name, value)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"jdk.sh/meta"
+)
+
+// bazelKeyPrefix is the common prefix of every Bazel stamp key. The STABLE_
+// prefix causes Bazel to rebuild stamped targets when the value changes.
+const bazelKeyPrefix = "STABLE_META_"
+
+// runBazel implements the bazel command.
+func runBazel(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	switch flags.Arg(0) {
+	case "status":
+		return bazelStatus(flags.Args()[1:], stdout)
+	case "x_defs":
+		if flags.NArg() != 1 {
+			flags.Usage()
+
+			return errUsage
+		}
+
+		return bazelXDefs(stdout)
+	default:
+		flags.Usage()
+
+		return errUsage
+	}
+}
+
+// bazelStatus prints a stamp key and value for every variable, in the format
+// expected from a Bazel --workspace_status_command. Values are derived from
+// git, along with any explicitly given values.
+func bazelStatus(args []string, stdout io.Writer) error {
+	values, info, err := collectValues(true, args)
+	if err != nil {
+		return err
+	}
+
+	status := map[string]string{
+		bazelKeyPrefix + "TAG":   info.tag,
+		bazelKeyPrefix + "DIRTY": strconv.FormatBool(info.dirty),
+	}
+
+	// Every variable is given a key, even if empty, as Bazel would otherwise
+	// leave the placeholder unexpanded.
+	for _, name := range meta.Variables() {
+		status[bazelKey(name)] = values[name]
+	}
+
+	keys := make([]string, 0, len(status))
+	for key := range status {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		// Values can not span multiple lines.
+		value := strings.ReplaceAll(status[key], "\n", " ")
+		if _, err := fmt.Fprintf(stdout, "%s %s\n", key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bazelXDefs prints an x_defs dict, for use with the rules_go go_binary rule,
+// that maps every variable to its stamp key.
+func bazelXDefs(stdout io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("x_defs = {\n")
+
+	for _, name := range meta.Variables() {
+		fmt.Fprintf(&b, "    %q: \"{%s}\",\n", name, bazelKey(name))
+	}
+
+	b.WriteString("},\n")
+
+	_, err := io.WriteString(stdout, b.String())
+
+	return err
+}
+
+// bazelKey returns the stamp key for the named variable. For example,
+// jdk.sh/meta.author_url becomes STABLE_META_AUTHOR_URL.
+func bazelKey(name string) string {
+	return bazelKeyPrefix + strings.ToUpper(strings.TrimPrefix(name, variablePrefix))
+}
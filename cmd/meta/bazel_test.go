@@ -0,0 +1,115 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestBazelKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"jdk.sh/meta.author_url": "STABLE_META_AUTHOR_URL",
+		"jdk.sh/meta.sha":        "STABLE_META_SHA",
+		"jdk.sh/meta.version":    "STABLE_META_VERSION",
+	}
+
+	for name, expected := range tests {
+		if actual := bazelKey(name); actual != expected {
+			t.Fatalf("expected %q but got %q", expected, actual)
+		}
+	}
+}
+
+func TestBazelXDefs(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := bazelXDefs(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"x_defs = {\n",
+		`    "jdk.sh/meta.sha": "{STABLE_META_SHA}",` + "\n",
+		`    "jdk.sh/meta.version": "{STABLE_META_VERSION}",` + "\n",
+		"},\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected output to contain %q but got %q", expected, buf.String())
+		}
+	}
+}
+
+// TestBazelStatus changes the working directory, and so must not run in
+// parallel.
+func TestBazelStatus(t *testing.T) { // nolint:paralleltest
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Jane Doe",
+			"GIT_AUTHOR_EMAIL=jdoe@example.com",
+			"GIT_COMMITTER_NAME=Jane Doe",
+			"GIT_COMMITTER_EMAIL=jdoe@example.com",
+		)
+
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+
+		return strings.TrimSpace(string(output))
+	}
+
+	run("init", "--quiet")
+	run("commit", "--quiet", "--allow-empty", "--message", "Initial commit")
+	run("tag", "v1.2.3")
+	sha := run("rev-parse", "HEAD")
+
+	// The git metadata is read from the current directory.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	var buf bytes.Buffer
+	if err := bazelStatus([]string{"name=demo-app"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"STABLE_META_DIRTY false\n",
+		"STABLE_META_NAME demo-app\n",
+		"STABLE_META_SHA " + sha + "\n",
+		"STABLE_META_TAG v1.2.3\n",
+		"STABLE_META_VERSION v1.2.3\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected output to contain %q but got %q", expected, buf.String())
+		}
+	}
+}
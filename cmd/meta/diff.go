@@ -0,0 +1,69 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"jdk.sh/meta/format"
+	"jdk.sh/meta/inspect"
+)
+
+// runDiff implements the diff command.
+func runDiff(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	output := outputFlag(flags, format.Text, format.JSON)
+	if err := parse(flags, args, 2); err != nil { // nolint:gomnd
+		return err
+	}
+
+	old, err := inspect.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	new, err := inspect.Open(flags.Arg(1)) // nolint:predeclared
+	if err != nil {
+		return err
+	}
+
+	changes := inspect.Diff(old, new)
+
+	switch *output {
+	case format.JSON:
+		if changes == nil {
+			changes = []inspect.Change{}
+		}
+
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(changes)
+
+	case format.Text:
+		writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0) // nolint:gomnd
+
+		for _, change := range changes {
+			fmt.Fprintf(writer, "%s\t%s\t%s -> %s\n", change.Kind, change.Key, orNone(change.Old), orNone(change.New))
+		}
+
+		return writer.Flush()
+
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+}
+
+// orNone returns the given value, or a placeholder if it is empty.
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+
+	return value
+}
@@ -0,0 +1,156 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"jdk.sh/meta"
+)
+
+// runGenerate implements the generate command.
+func runGenerate(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	var (
+		output  = flags.String("o", "zz_meta_gen.go", `output file, or "-" for stdout`)
+		pkg     = flags.String("package", packageName(), "package name of the generated file")
+		fromGit = flags.Bool("git", false, "derive the date, sha, and version from git")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	values, _, err := collectValues(*fromGit, flags.Args())
+	if err != nil {
+		return err
+	}
+
+	source, err := generate(*pkg, values)
+	if err != nil {
+		return err
+	}
+
+	if *output == "-" {
+		_, err := stdout.Write(source)
+
+		return err
+	}
+
+	return os.WriteFile(*output, source, 0o644) // nolint:gosec,gomnd
+}
+
+// generate returns Go source code for a file, in the named package, that
+// registers the given values at init.
+func generate(pkg string, values map[string]string) ([]byte, error) {
+	// Validate the values now, rather than when the application starts.
+	if _, err := meta.Parse(values); err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, name := range meta.Variables() {
+		known[name] = true
+	}
+
+	names := make([]string, 0, len(values))
+
+	for name := range values {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown variable %s", name)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by meta generate. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintln(&buf, `import "jdk.sh/meta"`)
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "func init() {")
+	fmt.Fprintln(&buf, "meta.Register(map[string]string{")
+
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s: %s,\n", strconv.Quote(name), strconv.Quote(values[name]))
+	}
+
+	fmt.Fprintln(&buf, "})")
+	fmt.Fprintln(&buf, "}")
+
+	return format.Source(buf.Bytes())
+}
+
+// packageName returns the name of the package being generated, as given by go
+// generate, or main otherwise.
+func packageName() string {
+	if pkg := os.Getenv("GOPACKAGE"); pkg != "" {
+		return pkg
+	}
+
+	return "main"
+}
+
+// collectValues returns the values, keyed by variable name, given by the
+// name=value arguments, and optionally derived from git. Explicit values take
+// precedence over values derived from git. The git metadata is also returned,
+// or nil if not requested.
+func collectValues(fromGit bool, args []string) (map[string]string, *gitInfo, error) {
+	values := make(map[string]string)
+
+	var info *gitInfo
+
+	if fromGit {
+		var err error
+		if info, err = readGit(); err != nil {
+			return nil, nil, fmt.Errorf("reading git metadata: %w", err)
+		}
+
+		values = info.values()
+	}
+
+	explicit, err := parseValues(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for name, value := range explicit {
+		values[name] = value
+	}
+
+	// Validate the values now, rather than when the application starts.
+	if _, err := meta.Parse(values); err != nil {
+		return nil, nil, err
+	}
+
+	return values, info, nil
+}
+
+// parseValues parses the given name=value arguments, keyed by variable name.
+func parseValues(args []string) (map[string]string, error) {
+	values := make(map[string]string, len(args))
+
+	for _, arg := range args {
+		name, value, found := strings.Cut(arg, "=")
+		if !found {
+			return nil, fmt.Errorf("malformed argument %q, must be name=value", arg)
+		}
+
+		values[variableName(name)] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,71 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values   map[string]string
+		expected string
+		fails    bool
+	}{
+		{
+			values: map[string]string{
+				"jdk.sh/meta.version": "v1.2.3",
+				"jdk.sh/meta.note":    `Say "hello"`,
+			},
+			expected: `// Code generated by meta generate. DO NOT EDIT.
+
+package main
+
+import "jdk.sh/meta"
+
+func init() {
+	meta.Register(map[string]string{
+		"jdk.sh/meta.note":    "Say \"hello\"",
+		"jdk.sh/meta.version": "v1.2.3",
+	})
+}
+`,
+		},
+		{
+			values: map[string]string{
+				"jdk.sh/meta.url": "example.com/page",
+			},
+			fails: true,
+		},
+		{
+			values: map[string]string{
+				"jdk.sh/meta.lisense": "MIT",
+			},
+			fails: true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := generate("main", test.values)
+
+			switch {
+			case err != nil && !test.fails:
+				t.Fatalf("expected success but got %v", err)
+			case err == nil && test.fails:
+				t.Fatal("expected failure but got success")
+			case string(actual) != test.expected:
+				t.Fatalf("expected %q but got %q", test.expected, actual)
+			}
+		})
+	}
+}
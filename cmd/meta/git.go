@@ -0,0 +1,75 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gitInfo is the metadata that can be derived from a git repository.
+type gitInfo struct {
+	// sha is the full SHA of the current commit.
+	sha string
+
+	// tag is the nearest tag, as given by git describe, or empty if the
+	// repository has no tags.
+	tag string
+
+	// dirty reports if the working tree has uncommitted changes.
+	dirty bool
+
+	// date is the current time, to be used as the build date.
+	date time.Time
+}
+
+// readGit derives metadata from the git repository in the current directory.
+func readGit() (*gitInfo, error) {
+	sha, err := git("rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := git("status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	// A repository without any tags is not an error.
+	tag, _ := git("describe", "--tags")
+
+	return &gitInfo{
+		sha:   sha,
+		tag:   tag,
+		dirty: status != "",
+		date:  time.Now().UTC(),
+	}, nil
+}
+
+// values returns the variable values, keyed by variable name, that correspond
+// to the git metadata.
+func (g *gitInfo) values() map[string]string {
+	values := map[string]string{
+		"jdk.sh/meta.date": g.date.Format(time.RFC3339),
+		"jdk.sh/meta.sha":  g.sha,
+	}
+
+	if g.tag != "" {
+		values["jdk.sh/meta.version"] = g.tag
+	}
+
+	return values
+}
+
+// git runs git with the given arguments, and returns its trimmed output.
+func git(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,110 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"jdk.sh/meta"
+)
+
+// goreleaserTemplates maps variable names to the GoReleaser template that
+// provides their value. Variables without a matching GoReleaser template are
+// instead read from an environment variable.
+var goreleaserTemplates = map[string]string{
+	"jdk.sh/meta.date":    "{{ .Date }}",
+	"jdk.sh/meta.name":    "{{ .ProjectName }}",
+	"jdk.sh/meta.sha":     "{{ .FullCommit }}",
+	"jdk.sh/meta.version": "{{ .Version }}",
+}
+
+// runGoreleaser implements the goreleaser command.
+func runGoreleaser(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	check := flags.String("check", "", "validate the meta variables in an existing config file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	if *check != "" {
+		return goreleaserCheck(*check, stdout)
+	}
+
+	return goreleaserLdflags(stdout)
+}
+
+// goreleaserLdflags prints the ldflags section of a GoReleaser build that
+// sets every variable. The symbol table is not stripped, since meta stamp and
+// meta inspect use it to locate the variables.
+func goreleaserLdflags(stdout io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("builds:\n")
+	b.WriteString("  - ldflags:\n")
+
+	for _, name := range meta.Variables() {
+		// Quoted so that values containing spaces remain a single flag.
+		fmt.Fprintf(&b, "      - -X '%s=%s'\n", name, goreleaserTemplate(name))
+	}
+
+	_, err := io.WriteString(stdout, b.String())
+
+	return err
+}
+
+// goreleaserCheck prints every misspelled or missing variable name in the
+// given GoReleaser config file, and returns an error if there were any.
+func goreleaserCheck(filename string, stdout io.Writer) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	references := findReferences(data)
+	problems := reportUnknown(filename, references, stdout)
+
+	referenced := make(map[string]bool)
+	for _, ref := range references {
+		referenced[ref.name] = true
+	}
+
+	for _, name := range meta.Variables() {
+		if !referenced[name] {
+			problems++
+
+			fmt.Fprintf(stdout, "%s: missing variable %s\n", filename, name)
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("found %d problem(s) in %s", problems, filename)
+	}
+
+	return nil
+}
+
+// goreleaserTemplate returns the GoReleaser template that provides the value
+// for the named variable. For example, jdk.sh/meta.author_url is read from
+// the META_AUTHOR_URL environment variable.
+func goreleaserTemplate(name string) string {
+	if template, found := goreleaserTemplates[name]; found {
+		return template
+	}
+
+	// Indexing the environment, rather than using .Env.NAME, evaluates to an
+	// empty value if the environment variable is unset, instead of failing.
+	key := "META_" + strings.ToUpper(strings.TrimPrefix(name, variablePrefix))
+
+	return fmt.Sprintf(`{{ index .Env %q }}`, key)
+}
@@ -0,0 +1,106 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoreleaserTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"jdk.sh/meta.author_url": `{{ index .Env "META_AUTHOR_URL" }}`,
+		"jdk.sh/meta.sha":        "{{ .FullCommit }}",
+		"jdk.sh/meta.version":    "{{ .Version }}",
+	}
+
+	for name, expected := range tests {
+		if actual := goreleaserTemplate(name); actual != expected {
+			t.Fatalf("expected %q but got %q", expected, actual)
+		}
+	}
+}
+
+func TestGoreleaserLdflags(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := goreleaserLdflags(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "builds:\n  - ldflags:\n      - -X 'jdk.sh/meta.author={{ index .Env \"META_AUTHOR\" }}'\n"
+	if !strings.HasPrefix(buf.String(), expected) {
+		t.Fatalf("expected output to start with %q but got %q", expected, buf.String())
+	}
+
+	if !strings.Contains(buf.String(), "      - -X 'jdk.sh/meta.version={{ .Version }}'\n") {
+		t.Fatalf("expected output to set the version but got %q", buf.String())
+	}
+}
+
+func TestGoreleaserCheck(t *testing.T) {
+	t.Parallel()
+
+	// A config generated by the goreleaser command must pass its own check.
+	var config bytes.Buffer
+	if err := goreleaserLdflags(&config); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := filepath.Join(t.TempDir(), ".goreleaser.yaml")
+	if err := os.WriteFile(valid, config.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := goreleaserCheck(valid, &buf); err != nil {
+		t.Fatalf("expected no error but got %v: %s", err, buf.String())
+	}
+
+	// Misspell one variable, which is then also reported as missing.
+	invalid := filepath.Join(t.TempDir(), ".goreleaser.yaml")
+	contents := strings.Replace(config.String(), "jdk.sh/meta.license_url", "jdk.sh/meta.licence_url", 1)
+
+	if err := os.WriteFile(invalid, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+
+	if err := goreleaserCheck(invalid, &buf); err == nil {
+		t.Fatal("expected an error but got none")
+	}
+
+	for _, expected := range []string{
+		"unknown variable jdk.sh/meta.licence_url, did you mean jdk.sh/meta.license_url?",
+		"missing variable jdk.sh/meta.license_url",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected output to contain %q but got %q", expected, buf.String())
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"jdk.sh/meta.verison":   "jdk.sh/meta.version",
+		"jdk.sh/meta.authorurl": "jdk.sh/meta.author_url",
+		"jdk.sh/meta.nonsense":  "",
+	}
+
+	for name, expected := range tests {
+		if actual := suggest(name); actual != expected {
+			t.Fatalf("expected %q for %s but got %q", expected, name, actual)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"jdk.sh/meta/format"
+	"jdk.sh/meta/inspect"
+)
+
+// runInspect implements the inspect command.
+func runInspect(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	output := outputFlag(flags, format.Text, format.JSON)
+	if err := parse(flags, args, 1); err != nil {
+		return err
+	}
+
+	binary, err := inspect.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case format.JSON:
+		var message string
+		if binary.Err != nil {
+			message = binary.Err.Error()
+		}
+
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			*inspect.Binary
+			Error string `json:"error,omitempty"`
+		}{binary, message})
+
+	case format.Text:
+		if err := format.WriteSnapshot(stdout, format.Text, binary.Metadata); err != nil {
+			return err
+		}
+
+		if binary.Err != nil {
+			fmt.Fprintf(stdout, "\nerror: binary will panic when executed: %v\n", binary.Err)
+		}
+
+		if binary.BuildInfo != nil {
+			fmt.Fprintf(stdout, "\n%s", binary.BuildInfo)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"jdk.sh/meta"
+)
+
+// runLdflags implements the ldflags command.
+func runLdflags(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	fromGit := flags.Bool("git", false, "derive the date, sha, and version from git")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	values, _, err := collectValues(*fromGit, flags.Args())
+	if err != nil {
+		return err
+	}
+
+	var flagValues []string
+
+	for _, name := range meta.Variables() {
+		value, found := values[name]
+		if !found {
+			continue
+		}
+
+		// The go command does not support escaping within quotes, so a value
+		// can only be quoted with a character that it does not contain.
+		switch definition := name + "=" + value; {
+		case !strings.Contains(definition, "'"):
+			flagValues = append(flagValues, "-X '"+definition+"'")
+		case !strings.Contains(definition, `"`):
+			flagValues = append(flagValues, `-X "`+definition+`"`)
+		default:
+			return fmt.Errorf("value for variable %s can not contain both single and double quotes", name)
+		}
+	}
+
+	_, err = fmt.Fprintln(stdout, strings.Join(flagValues, " "))
+
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lintFilenames is the set of build files, by exact name, that are scanned.
+var lintFilenames = map[string]bool{
+	".goreleaser.yaml": true,
+	".goreleaser.yml":  true,
+	"BUILD":            true,
+	"BUILD.bazel":      true,
+	"GNUmakefile":      true,
+	"Makefile":         true,
+	"WORKSPACE":        true,
+	"WORKSPACE.bazel":  true,
+	"goreleaser.yaml":  true,
+	"goreleaser.yml":   true,
+	"makefile":         true,
+}
+
+// lintExtensions is the set of build files, by extension, that are scanned.
+var lintExtensions = map[string]bool{
+	".bash": true,
+	".bzl":  true,
+	".mk":   true,
+	".sh":   true,
+}
+
+// lintSkipDirs is the set of directories that are never descended into.
+var lintSkipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// runLint implements the lint command.
+func runLint(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var problems int
+
+	for _, path := range paths {
+		count, err := lintPath(path, stdout)
+		if err != nil {
+			return err
+		}
+
+		problems += count
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("found %d unknown variable reference(s)", problems)
+	}
+
+	return nil
+}
+
+// lintPath scans the given file, or every build file under the given
+// directory, and prints every reference to a variable that does not exist.
+// The number of references printed is returned. A file that is named
+// explicitly is always scanned, even if it is not a recognized build file.
+func lintPath(root string, stdout io.Writer) (int, error) {
+	var problems int
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case entry.IsDir() && path != root && lintSkipDirs[entry.Name()]:
+			return filepath.SkipDir
+		case entry.IsDir():
+			return nil
+		case path != root && !lintable(entry.Name()):
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		problems += reportUnknown(path, findReferences(data), stdout)
+
+		return nil
+	})
+
+	return problems, err
+}
+
+// lintable reports if the named file is a recognized build file.
+func lintable(name string) bool {
+	return lintFilenames[name] || lintExtensions[strings.ToLower(filepath.Ext(name))]
+}
@@ -0,0 +1,69 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintPath(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"Makefile":               "build:\n\tgo build -ldflags \"-X jdk.sh/meta.lisense=MIT\" .\n",
+		"scripts/build.sh":       "go build -ldflags '-X jdk.sh/meta.version=v1.2.3' .\n",
+		"BUILD.bazel":            "x_defs = {\"jdk.sh/meta.sha\": \"{STABLE_META_SHA}\"},\n",
+		"README.md":              "-X jdk.sh/meta.ignored=true\n",
+		"vendor/lib/Makefile":    "-X jdk.sh/meta.ignored=true\n",
+		"deploy/.goreleaser.yml": "ldflags:\n  - -X jdk.sh/meta.verison={{ .Version }}\n",
+	}
+
+	root := t.TempDir()
+
+	for name, contents := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	problems, err := lintPath(root, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if problems != 2 { // nolint:gomnd
+		t.Fatalf("expected 2 problems but got %d: %s", problems, buf.String())
+	}
+
+	for _, expected := range []string{
+		filepath.Join(root, "Makefile") + ":2:24: unknown variable jdk.sh/meta.lisense, did you mean jdk.sh/meta.license?",
+		"unknown variable jdk.sh/meta.verison, did you mean jdk.sh/meta.version?",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected output to contain %q but got %q", expected, buf.String())
+		}
+	}
+
+	// A file that is named explicitly is scanned regardless of its name.
+	problems, err = lintPath(filepath.Join(root, "README.md"), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if problems != 1 {
+		t.Fatalf("expected 1 problem but got %d", problems)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+// Command meta is a tool for working with the application metadata that is
+// embedded into binaries by jdk.sh/meta.
+//
+// Usage:
+//
+//	meta bazel status [name=value...]
+//	meta bazel x_defs
+//	meta diff [-o text|json] <old> <new>
+//	meta generate [-git] [-o file] [-package name] [name=value...]
+//	meta goreleaser [-check file]
+//	meta inspect [-o text|json] <binary>
+//	meta ldflags [-git] [name=value...]
+//	meta lint [path...]
+//	meta reserve [-size n] <name>...
+//	meta stamp <binary> <name=value>...
+//	meta variables [-o text|json|markdown]
+//	meta version [-o text|json|yaml]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"jdk.sh/meta/format"
+)
+
+// command is a single meta subcommand.
+type command struct {
+	// usage is the argument synopsis for the command.
+	usage string
+
+	// description is a short description of what the command does.
+	description string
+
+	// run executes the command with the given arguments.
+	run func(flags *flag.FlagSet, args []string, stdout io.Writer) error
+}
+
+// commands is every meta subcommand, keyed by name.
+var commands = map[string]command{
+	"bazel": {
+		usage:       "status [name=value...] | x_defs",
+		description: "Print a Bazel workspace status, or the matching rules_go x_defs",
+		run:         runBazel,
+	},
+	"diff": {
+		usage:       "[-o text|json] <old> <new>",
+		description: "Print the metadata differences between two binaries",
+		run:         runDiff,
+	},
+	"generate": {
+		usage:       "[-git] [-o file] [-package name] [name=value...]",
+		description: "Write a Go source file that registers metadata at init",
+		run:         runGenerate,
+	},
+	"goreleaser": {
+		usage:       "[-check file]",
+		description: "Print GoReleaser ldflags, or validate those in an existing config",
+		run:         runGoreleaser,
+	},
+	"inspect": {
+		usage:       "[-o text|json] <binary>",
+		description: "Print the metadata embedded in a binary, without executing it",
+		run:         runInspect,
+	},
+	"ldflags": {
+		usage:       "[-git] [name=value...]",
+		description: "Print the ldflags for setting metadata with go build",
+		run:         runLdflags,
+	},
+	"lint": {
+		usage:       "[path...]",
+		description: "Report references to unknown variables in build files",
+		run:         runLint,
+	},
+	"reserve": {
+		usage:       "[-size n] <name>...",
+		description: "Print ldflags that reserve space for variables to be stamped",
+		run:         runReserve,
+	},
+	"stamp": {
+		usage:       "<binary> <name=value>...",
+		description: "Rewrite the reserved metadata in a binary, without rebuilding it",
+		run:         runStamp,
+	},
+	"variables": {
+		usage:       "[-o text|json|markdown]",
+		description: "Print every variable that can be set, and what it is used for",
+		run:         runVariables,
+	},
+	"version": {
+		usage:       "[-o text|json|yaml]",
+		description: "Print the version information for this tool",
+		run:         runVersion,
+	},
+}
+
+// errUsage is returned when a command is invoked with invalid arguments.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "meta: %v\n", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+// run executes the subcommand named by the first argument.
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+
+		return errUsage
+	}
+
+	cmd, found := commands[args[0]]
+	if !found {
+		usage(stderr)
+
+		return errUsage
+	}
+
+	flags := flag.NewFlagSet("meta "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: meta %s %s\n\n%s.\n", args[0], cmd.usage, cmd.description)
+
+		if hasFlags(flags) {
+			fmt.Fprintln(stderr)
+			flags.PrintDefaults()
+		}
+	}
+
+	return cmd.run(flags, args[1:], stdout)
+}
+
+// usage prints the list of available commands.
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage: meta <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].description)
+	}
+}
+
+// hasFlags reports if any flags were defined on the given flag set.
+func hasFlags(flags *flag.FlagSet) bool {
+	var found bool
+
+	flags.VisitAll(func(*flag.Flag) {
+		found = true
+	})
+
+	return found
+}
+
+// parse parses the given arguments, and requires the given number of
+// positional arguments to remain.
+func parse(flags *flag.FlagSet, args []string, count int) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != count {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	return nil
+}
+
+// outputFlag defines an -o flag for selecting an output format.
+func outputFlag(flags *flag.FlagSet, formats ...string) *string {
+	return flags.String("o", format.Text, fmt.Sprintf("output format, one of %s", strings.Join(formats, "|")))
+}
+
+// runVersion implements the version command.
+func runVersion(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	output := outputFlag(flags, format.Formats...)
+	if err := parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	return format.Write(stdout, *output)
+}
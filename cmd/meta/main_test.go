@@ -0,0 +1,145 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	// The test binary itself is a perfectly good binary to inspect.
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args     []string
+		expected string
+		err      error
+	}{
+		{
+			err: errUsage,
+		},
+		{
+			args: []string{"missing"},
+			err:  errUsage,
+		},
+		{
+			args: []string{"inspect"},
+			err:  errUsage,
+		},
+		{
+			args:     []string{"generate", "-o", "-", "-package", "demo", "version=v1.2.3"},
+			expected: "package demo\n",
+		},
+		{
+			args:     []string{"inspect", self},
+			expected: "go:   " + runtime.Version() + "\n",
+		},
+		{
+			args:     []string{"inspect", "-o", "json", self},
+			expected: `"go": "` + runtime.Version() + `"`,
+		},
+		{
+			args: []string{"bazel", "missing"},
+			err:  errUsage,
+		},
+		{
+			args: []string{"diff", self},
+			err:  errUsage,
+		},
+		{
+			// A binary never differs from itself.
+			args:     []string{"diff", "-o", "json", self, self},
+			expected: "[]\n",
+		},
+		{
+			args:     []string{"ldflags", "name=demo-app", `note=it's`},
+			expected: `-X 'jdk.sh/meta.name=demo-app' -X "jdk.sh/meta.note=it's"` + "\n",
+		},
+		{
+			args:     []string{"reserve", "-size", "16", "version", "jdk.sh/meta.note"},
+			expected: "-X 'jdk.sh/meta.version=META_RESERVED___'\n-X 'jdk.sh/meta.note=META_RESERVED___'\n",
+		},
+		{
+			args: []string{"stamp", self},
+			err:  errUsage,
+		},
+		{
+			args:     []string{"variables", "-o", "markdown"},
+			expected: "| `jdk.sh/meta.sha`         | Git SHA",
+		},
+		{
+			args:     []string{"variables", "-o", "json"},
+			expected: `"path": "jdk.sh/meta.version"`,
+		},
+		{
+			args:     []string{"version", "-o", "yaml"},
+			expected: `go: "` + runtime.Version() + `"`,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			err := run(test.args, &stdout, &stderr)
+
+			switch {
+			case test.err != nil && !errors.Is(err, test.err):
+				t.Fatalf("expected error %v but got %v", test.err, err)
+			case test.err == nil && err != nil:
+				t.Fatalf("expected success but got %v", err)
+			case !strings.Contains(stdout.String(), test.expected):
+				t.Fatalf("expected output to contain %q but got %q", test.expected, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunReserveUnknown(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	err := run([]string{"reserve", "version", "lisense"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "unknown variable jdk.sh/meta.lisense") {
+		t.Fatalf("expected an unknown variable error but got %v", err)
+	}
+
+	if stdout.Len() != 0 {
+		t.Fatalf("expected no output but got %q", stdout.String())
+	}
+}
+
+// TestUsageDocumented verifies that every command is listed in the package
+// documentation.
+func TestUsageDocumented(t *testing.T) {
+	t.Parallel()
+
+	source, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name := range commands {
+		if !strings.Contains(string(source), "//\tmeta "+name+" ") {
+			t.Fatalf("expected package documentation to list the %s command", name)
+		}
+	}
+}
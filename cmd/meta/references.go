@@ -0,0 +1,147 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+
+	"jdk.sh/meta"
+)
+
+// referenceRegex matches a reference to any variable name.
+var referenceRegex = regexp.MustCompile(`jdk\.sh/meta\.[A-Za-z0-9_]+`)
+
+// reference is a single reference to a variable name in a file.
+type reference struct {
+	// name is the referenced variable name.
+	name string
+
+	// line is the 1-indexed line number of the reference.
+	line int
+
+	// column is the 1-indexed column number of the reference.
+	column int
+}
+
+// findReferences returns every reference to a variable name in the given
+// data, whether or not the variable actually exists.
+func findReferences(data []byte) []reference {
+	var references []reference
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+
+	for line := 1; scanner.Scan(); line++ {
+		for _, match := range referenceRegex.FindAllIndex(scanner.Bytes(), -1) {
+			references = append(references, reference{
+				name:   string(scanner.Bytes()[match[0]:match[1]]),
+				line:   line,
+				column: match[0] + 1,
+			})
+		}
+	}
+
+	return references
+}
+
+// reportUnknown prints every reference, found in the named file, to a
+// variable that does not exist, along with a suggested correction. The number
+// of references printed is returned.
+func reportUnknown(filename string, references []reference, w io.Writer) int {
+	var count int
+
+	for _, ref := range references {
+		if isVariable(ref.name) {
+			continue
+		}
+
+		count++
+
+		message := fmt.Sprintf("%s:%d:%d: unknown variable %s", filename, ref.line, ref.column, ref.name)
+		if suggestion := suggest(ref.name); suggestion != "" {
+			message += fmt.Sprintf(", did you mean %s?", suggestion)
+		}
+
+		fmt.Fprintln(w, message)
+	}
+
+	return count
+}
+
+// isVariable reports if the given name is a real variable name.
+func isVariable(name string) bool {
+	for _, variable := range meta.Variables() {
+		if name == variable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suggest returns the real variable name that is closest to the given,
+// presumably misspelled, name, or an empty string if none are close.
+func suggest(name string) string {
+	// The maximum edit distance for a suggestion to be made.
+	const maxDistance = 3
+
+	var (
+		best         string
+		bestDistance = maxDistance + 1
+	)
+
+	for _, variable := range meta.Variables() {
+		if distance := levenshtein(name, variable); distance < bestDistance {
+			best, bestDistance = variable, distance
+		}
+	}
+
+	return best
+}
+
+// levenshtein returns the edit distance between the two given strings.
+// See https://en.wikipedia.org/wiki/Levenshtein_distance.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// min returns the smallest of the given values.
+func min(values ...int) int { // nolint:predeclared
+	result := values[0]
+
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
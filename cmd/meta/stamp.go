@@ -0,0 +1,81 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"jdk.sh/meta"
+	"jdk.sh/meta/inspect"
+)
+
+// variablePrefix is the common prefix of every variable name.
+const variablePrefix = "jdk.sh/meta."
+
+// runReserve implements the reserve command.
+func runReserve(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	size := flags.Int("size", 64, "number of bytes to reserve for each variable") // nolint:gomnd
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	known := make(map[string]bool)
+	for _, name := range meta.Variables() {
+		known[name] = true
+	}
+
+	// Every name is checked before anything is printed, so that a misspelled
+	// name does not result in partial output.
+	for _, name := range flags.Args() {
+		if !known[variableName(name)] {
+			return fmt.Errorf("unknown variable %s", variableName(name))
+		}
+	}
+
+	for _, name := range flags.Args() {
+		fmt.Fprintf(stdout, "-X '%s=%s'\n", variableName(name), meta.Reserve(*size))
+	}
+
+	return nil
+}
+
+// runStamp implements the stamp command.
+func runStamp(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() < 2 { // nolint:gomnd
+		flags.Usage()
+
+		return errUsage
+	}
+
+	values, err := parseValues(flags.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	return inspect.Stamp(flags.Arg(0), values)
+}
+
+// variableName returns the full variable name for the given name, which may
+// omit the common jdk.sh/meta. prefix.
+func variableName(name string) string {
+	if strings.HasPrefix(name, variablePrefix) {
+		return name
+	}
+
+	return variablePrefix + name
+}
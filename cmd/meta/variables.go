@@ -0,0 +1,92 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"jdk.sh/meta"
+	"jdk.sh/meta/format"
+)
+
+// markdownFormat is an output format that prints a markdown table, as used in
+// the README.
+const markdownFormat = "markdown"
+
+// runVariables implements the variables command.
+func runVariables(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	output := outputFlag(flags, format.Text, format.JSON, markdownFormat)
+	if err := parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	fields := meta.Fields()
+
+	switch *output {
+	case format.JSON:
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(fields)
+
+	case format.Text:
+		writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0) // nolint:gomnd
+
+		for _, field := range fields {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", field.Path, field.Type, field.Description)
+		}
+
+		return writer.Flush()
+
+	case markdownFormat:
+		_, err := io.WriteString(stdout, variablesTable(fields))
+
+		return err
+
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+}
+
+// variablesTable returns a markdown table of the name and description of the
+// given fields, with aligned columns.
+func variablesTable(fields []meta.Field) string {
+	rows := [][2]string{{"Name", "Purpose"}, {}}
+	for _, field := range fields {
+		rows = append(rows, [2]string{"`" + field.Path + "`", field.Description})
+	}
+
+	var widths [2]int
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var b strings.Builder
+
+	for n, row := range rows {
+		for i, cell := range row {
+			// The second row separates the header from the body.
+			if n == 1 {
+				cell = strings.Repeat("-", widths[i])
+			}
+
+			fmt.Fprintf(&b, "| %-*s ", widths[i], cell)
+		}
+
+		b.WriteString("|\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,60 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envPrefix is the common prefix of every environment variable that can
+// override a variable value.
+const envPrefix = "META_"
+
+// envName returns the name of the environment variable that overrides the
+// named variable. For example, jdk.sh/meta.author_url is overridden by
+// META_AUTHOR_URL.
+func envName(path string) string {
+	return envPrefix + strings.ToUpper(strings.TrimPrefix(path, "jdk.sh/meta."))
+}
+
+// loadOverrides sets the override value of every field from its environment
+// variable, but only if overrides are enabled. Overrides are enabled if the
+// application was built with the meta_env build tag, or if the application is
+// in development mode, as determined without any overrides. Any malformed
+// value causes a panic.
+func loadOverrides() {
+	for _, f := range fields {
+		f.override = ""
+	}
+
+	dev, _ := lookupField("jdk.sh/meta.dev").resolve()
+	if !envTag && !mustBool("jdk.sh/meta.dev", dev) {
+		return
+	}
+
+	for _, f := range fields {
+		f.override = os.Getenv(envName(f.path))
+	}
+
+	// Placeholders are expanded using the resolved version and SHA, which
+	// might themselves have been overridden.
+	raw := make(map[string]string, len(fields))
+	for _, f := range fields {
+		raw[f.path], _ = f.resolve()
+	}
+
+	for _, f := range fields {
+		value := f.override
+		if f.expand {
+			value = expandVersioned(value, raw)
+		}
+
+		if _, err := f.tryParse(value); err != nil {
+			panic(fmt.Errorf("malformed environment value for %s", envName(f.path)))
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+//go:build !meta_env
+
+package meta
+
+// envTag is false, since the application was built without the meta_env build
+// tag. Environment variable overrides are then only enabled in development
+// mode.
+const envTag = false
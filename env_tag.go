@@ -0,0 +1,11 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+//go:build meta_env
+
+package meta
+
+// envTag is true, since the application was built with the meta_env build
+// tag, which always enables environment variable overrides.
+const envTag = true
@@ -0,0 +1,72 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"testing"
+)
+
+func TestEnvName(t *testing.T) {
+	t.Parallel()
+
+	equalString(t, "META_AUTHOR_URL", envName("jdk.sh/meta.author_url"))
+	equalString(t, "META_VERSION", envName("jdk.sh/meta.version"))
+}
+
+// TestOverrides modifies global state, and so must not run in parallel.
+func TestOverrides(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+	t.Setenv("META_VERSION", "v1.2.3")
+	t.Setenv("META_URL", "https://example.com/page")
+
+	// Overrides are ignored outside of development mode, unless built with
+	// the meta_env build tag.
+	version = "v1.0.0"
+	parse()
+
+	if !envTag {
+		equalString(t, "v1.0.0", Version())
+
+		if URL() != nil {
+			t.Fatalf("expected no URL but got %v", URL())
+		}
+	}
+
+	// Overrides take precedence over every other value in development mode.
+	dev = "true"
+	parse()
+
+	equalString(t, "v1.2.3", Version())
+	equalString(t, "3", VersionPatch())
+	equalString(t, "https://example.com/page", URL().String())
+	equalString(t, SourceEnv, Data().Sources["jdk.sh/meta.version"])
+	equalString(t, SourceLdflags, Data().Sources["jdk.sh/meta.dev"])
+}
+
+// TestOverridesExpanded modifies global state, and so must not run in
+// parallel.
+func TestOverridesExpanded(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+	t.Setenv("META_DOCS", "https://v{major}.docs.example.com/")
+	t.Setenv("META_VERSION", "v2.0.0")
+
+	dev = "true"
+	parse()
+
+	equalString(t, "https://v2.docs.example.com/", Docs().String())
+	equalString(t, SourceEnv, Data().Sources["jdk.sh/meta.docs"])
+}
+
+// TestOverridesMalformed modifies global state, and so must not run in
+// parallel.
+func TestOverridesMalformed(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+	t.Setenv("META_URL", "example.com/page")
+
+	dev = "true"
+
+	defer equalPanic(t, true)
+	parse()
+}
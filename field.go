@@ -0,0 +1,350 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+// List of field types.
+const (
+	// TypeString is a field with a free-form string value.
+	TypeString = "string"
+
+	// TypeAuthor is a field with a name, email address, or both. The parsed
+	// value is a mail.Address.
+	TypeAuthor = "author"
+
+	// TypeBool is a field with a boolean value.
+	TypeBool = "bool"
+
+	// TypeSHA is a field with a 40 character git SHA value. The parsed value is
+	// a string.
+	TypeSHA = "sha"
+
+	// TypeSemver is a field with a version value, which may be a semver
+	// version. The parsed value is a Semver.
+	TypeSemver = "semver"
+
+	// TypeTime is a field with a timestamp value. The parsed value is a
+	// *time.Time.
+	TypeTime = "time"
+
+	// TypeURL is a field with an http:// or https:// URL value. The parsed
+	// value is a *url.URL.
+	TypeURL = "url"
+)
+
+// List of field sources.
+const (
+	// SourceDefault is a field that was not set, and has its default value.
+	SourceDefault = "default"
+
+	// SourceLdflags is a field that was set using -X arguments to ldflags.
+	SourceLdflags = "ldflags"
+
+	// SourceGenerated is a field that was set using Register, typically from
+	// code generated by the meta generate command.
+	SourceGenerated = "generated"
+
+	// SourceBuildInfo is a field that was not set, but was instead inferred
+	// from the build information embedded by the Go toolchain.
+	SourceBuildInfo = "buildinfo"
+
+	// SourceEnv is a field that was overridden using an environment variable.
+	SourceEnv = "env"
+)
+
+// Field describes a single variable that can be set using ldflags, along with
+// its current value.
+type Field struct {
+	// Path is the variable name, as used with -X arguments to ldflags.
+	Path string `json:"path"`
+
+	// Description is a description of what the variable is used for.
+	Description string `json:"description"`
+
+	// Type is the type of value that the variable holds. One of the Type
+	// constants.
+	Type string `json:"type"`
+
+	// Parse validates and converts a raw value, in the same manner as is done
+	// when the application starts. Version placeholders are expanded using
+	// the current version and SHA.
+	Parse func(raw string) (interface{}, error) `json:"-"`
+
+	// Raw is the current raw value of the variable. Placeholder values are
+	// treated as if they were not set.
+	Raw string `json:"raw,omitempty"`
+
+	// Value is the current parsed value of the variable, with any version
+	// placeholders expanded, or nil if the variable was not set.
+	Value interface{} `json:"value,omitempty"`
+
+	// Source is where the current value of the variable came from. One of the
+	// Source constants.
+	Source string `json:"source"`
+}
+
+// field is a single variable that can be set using ldflags. The source is only
+// recorded for values that were not set using ldflags. The override value takes
+// precedence over everything else, and the fallback value is used if the
+// variable was not set at all. Version placeholders, such as {version}, in the
+// value of an expanded field are replaced before it is parsed.
+type field struct {
+	path        string
+	description string
+	typ         string
+	variable    *string
+	source      string
+	override    string
+	fallback    string
+	expand      bool
+	parse       func(path, raw string) interface{}
+}
+
+// fields is every variable that can be set using ldflags, sorted by variable
+// name.
+var fields = []*field{
+	{
+		path:        "jdk.sh/meta.author",
+		description: "The name of the application author. May contain their name, email address, or optionally both.",
+		typ:         TypeAuthor,
+		variable:    &author,
+		parse:       parseAuthor,
+	},
+	{
+		path:        "jdk.sh/meta.author_url",
+		description: "URL for the application author. Typically links to the author's personal homepage or GitHub profile.",
+		typ:         TypeURL,
+		variable:    &author_url,
+		parse:       parseURL,
+	},
+	{
+		path:        "jdk.sh/meta.copyright",
+		description: "The copyright for the application. Typically the name of the author or organization, sometimes prefixed with a year or year range.", // nolint:lll
+		typ:         TypeString,
+		variable:    &copyright,
+		parse:       parseString,
+	},
+	{
+		path:        "jdk.sh/meta.date",
+		description: "The time that the application was built. Supports several common formats.",
+		typ:         TypeTime,
+		variable:    &date,
+		parse:       parseTime,
+	},
+	{
+		path:        "jdk.sh/meta.desc",
+		description: "Description for the application. Typically a longer statement describing what the application does.",
+		typ:         TypeString,
+		variable:    &desc,
+		parse:       parseString,
+	},
+	{
+		path:        "jdk.sh/meta.dev",
+		description: "The development status for the application. An application in development mode may indicate that it's using experimental or untested features, and should be used with caution.", // nolint:lll
+		typ:         TypeBool,
+		variable:    &dev,
+		parse:       parseBool,
+	},
+	{
+		path:        "jdk.sh/meta.docs",
+		description: "URL for application documentation. Typically links to a page where a user can find technical documentation.", // nolint:lll
+		typ:         TypeURL,
+		variable:    &docs,
+		expand:      true,
+		parse:       parseURL,
+	},
+	{
+		path:        "jdk.sh/meta.license",
+		description: "The license identifier for the application. Should not be the full license body, but one of the identifiers from https://spdx.org/licenses, so that the type of license can be easily determined.", // nolint:lll
+		typ:         TypeString,
+		variable:    &license,
+		parse:       parseString,
+	},
+	{
+		path:        "jdk.sh/meta.license_url",
+		description: "URL for the application license. Typically links to a page where the verbatim license body is available.", // nolint:lll
+		typ:         TypeURL,
+		variable:    &license_url,
+		expand:      true,
+		parse:       parseURL,
+	},
+	{
+		path:        "jdk.sh/meta.name",
+		description: "The name of the application. Typically named the same as the binary, or for display in an error or help message.", // nolint:lll
+		typ:         TypeString,
+		variable:    &name,
+		parse:       parseString,
+	},
+	{
+		path:        "jdk.sh/meta.note",
+		description: "An arbitrary message for the application. Can be used to store a message about the build environment, release, etc.", // nolint:lll
+		typ:         TypeString,
+		variable:    &note,
+		parse:       parseString,
+	},
+	{
+		path:        "jdk.sh/meta.sha",
+		description: `Git SHA that was used to build the application. A 40 character "long" SHA should be provided.`,
+		typ:         TypeSHA,
+		variable:    &sha,
+		parse:       parseSHA,
+	},
+	{
+		path:        "jdk.sh/meta.src",
+		description: "URL for the application source code. Typically links to a repository where a user can browse or clone the source code.", // nolint:lll
+		typ:         TypeURL,
+		variable:    &src,
+		parse:       parseSourceURL,
+	},
+	{
+		path:        "jdk.sh/meta.title",
+		description: "The title of the application. Typically a full or non-abbreviated form of the application name.",
+		typ:         TypeString,
+		variable:    &title,
+		parse:       parseString,
+	},
+	{
+		path:        "jdk.sh/meta.url",
+		description: "URL for the application homepage. Typically links to a page where a user can learn more about the application.", // nolint:lll
+		typ:         TypeURL,
+		variable:    &url,
+		parse:       parseURL,
+	},
+	{
+		path:        "jdk.sh/meta.version",
+		description: "The version slug for the application. The value can be used to point back to a specific tag or release. Supports semver, see https://semver.org.", // nolint:lll
+		typ:         TypeSemver,
+		variable:    &version,
+		parse:       parseSemver,
+	},
+}
+
+// Fields returns every variable that can be set using ldflags, along with its
+// current value, sorted by variable name.
+func Fields() []Field {
+	result := make([]Field, 0, len(fields))
+
+	m := global
+
+	for _, f := range fields {
+		f := f
+
+		parse := f.tryParse
+		if f.expand {
+			parse = func(raw string) (interface{}, error) {
+				return f.tryParse(expandVersioned(raw, m.raw))
+			}
+		}
+
+		result = append(result, Field{
+			Path:        f.path,
+			Description: f.description,
+			Type:        f.typ,
+			Parse:       parse,
+			Raw:         m.raw[f.path],
+			Value:       m.values[f.path],
+			Source:      m.sources[f.path],
+		})
+	}
+
+	return result
+}
+
+// resolve returns the current raw value of the field, along with where that
+// value came from.
+func (f *field) resolve() (string, string) {
+	switch raw := unreserved(*f.variable); {
+	case f.override != "":
+		return f.override, SourceEnv
+	case raw != "" && f.source != "":
+		return raw, f.source
+	case raw != "":
+		// A value that was not set by anything else must have been set using
+		// ldflags.
+		return raw, SourceLdflags
+	case f.fallback != "":
+		return f.fallback, SourceBuildInfo
+	default:
+		return "", SourceDefault
+	}
+}
+
+// tryParse validates and converts the given raw value, returning an error
+// rather than panicking if it is malformed.
+func (f *field) tryParse(raw string) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return f.parse(f.path, unreserved(raw)), nil
+}
+
+// lookupField returns the named field, or nil if there is no such field.
+func lookupField(path string) *field {
+	for _, f := range fields {
+		if f.path == path {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// parseAuthor converts a TypeAuthor value.
+func parseAuthor(path, raw string) interface{} {
+	name, email := mustAuthor(path, raw)
+
+	return mail.Address{Name: name, Address: email}
+}
+
+// parseBool converts a TypeBool value.
+func parseBool(path, raw string) interface{} {
+	return mustBool(path, raw)
+}
+
+// parseSemver converts a TypeSemver value.
+func parseSemver(path, raw string) interface{} {
+	major, minor, patch, preRelease, build := mustSemver(path, raw)
+
+	return Semver{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		PreRelease: preRelease,
+		Build:      build,
+	}
+}
+
+// parseSHA converts a TypeSHA value.
+func parseSHA(path, raw string) interface{} {
+	return mustSHA(path, raw)
+}
+
+// parseSourceURL converts a TypeURL value for the source code, which may also
+// be a git remote.
+func parseSourceURL(path, raw string) interface{} {
+	return mustSourceURL(path, raw)
+}
+
+// parseString converts a TypeString value, which is always valid.
+func parseString(_, raw string) interface{} {
+	return raw
+}
+
+// parseTime converts a TypeTime value.
+func parseTime(path, raw string) interface{} {
+	return mustTime(path, raw)
+}
+
+// parseURL converts a TypeURL value.
+func parseURL(path, raw string) interface{} {
+	return mustURL(path, raw)
+}
@@ -0,0 +1,125 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"net/mail"
+	u "net/url"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	all := Fields()
+	if len(all) != len(Variables()) {
+		t.Fatalf("expected %d fields but got %d", len(Variables()), len(all))
+	}
+
+	for i, name := range Variables() {
+		f := all[i]
+		equalString(t, name, f.Path)
+
+		if f.Description == "" || f.Type == "" || f.Parse == nil {
+			t.Fatalf("expected field %s to be fully described", f.Path)
+		}
+
+		// The test binary is built without any values.
+		equalString(t, SourceDefault, f.Source)
+
+		if f.Value != nil {
+			t.Fatalf("expected no value for field %s but got %v", f.Path, f.Value)
+		}
+	}
+}
+
+func TestFieldParse(t *testing.T) {
+	t.Parallel()
+
+	fields := make(map[string]Field)
+	for _, f := range Fields() {
+		fields[f.Path] = f
+	}
+
+	value, err := fields["jdk.sh/meta.author"].Parse("Jane Doe <jdoe@example.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != (mail.Address{Name: "Jane Doe", Address: "jdoe@example.com"}) {
+		t.Fatalf("unexpected author value %v", value)
+	}
+
+	value, err = fields["jdk.sh/meta.version"].Parse("v1.2.3-rc.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != (Semver{Major: "1", Minor: "2", Patch: "3", PreRelease: "rc.1"}) {
+		t.Fatalf("unexpected version value %v", value)
+	}
+
+	if _, err := fields["jdk.sh/meta.url"].Parse("example.com/page"); err == nil {
+		t.Fatal("expected an error but got none")
+	}
+}
+
+// TestFieldsExpanded modifies global state, and so must not run in parallel.
+func TestFieldsExpanded(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	// The placeholder in the host is not a valid URL until it is expanded.
+	docs = "https://v{major}.docs.example.com/{version}"
+	version = "v1.2.3"
+	parse()
+
+	fields := make(map[string]Field)
+	for _, f := range Fields() {
+		fields[f.Path] = f
+	}
+
+	field := fields["jdk.sh/meta.docs"]
+	equalString(t, "https://v{major}.docs.example.com/{version}", field.Raw)
+	equalString(t, "https://v1.docs.example.com/v1.2.3", field.Value.(*u.URL).String()) // nolint:forcetypeassert
+
+	value, err := field.Parse("https://v{major}.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equalString(t, "https://v1.example.com/", value.(*u.URL).String()) // nolint:forcetypeassert
+}
+
+// TestFieldsDocumented verifies that every field is listed in the package
+// documentation, and in the README variables table.
+func TestFieldsDocumented(t *testing.T) {
+	t.Parallel()
+
+	source, err := os.ReadFile("meta.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readme, err := os.ReadFile("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range Fields() {
+		if !strings.Contains(string(source), "//   "+f.Path+"\n") {
+			t.Fatalf("expected package documentation to list %s", f.Path)
+		}
+
+		name, description := regexp.QuoteMeta("`"+f.Path+"`"), regexp.QuoteMeta(f.Description)
+
+		row := regexp.MustCompile(`(?m)^\| ` + name + ` +\| ` + description + ` +\|$`)
+		if !row.Match(readme) {
+			t.Fatalf("expected README to describe %s as %q", f.Path, f.Description)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	u "net/url"
+	"strings"
+)
+
+// forge describes the URL layout of a code hosting service. Each layout is a
+// format string, relative to the repository URL, or empty if the service does
+// not support that kind of page.
+type forge struct {
+	// commit is the layout for a commit page, given a SHA.
+	commit string
+
+	// tree is the layout for browsing files, given a ref.
+	tree string
+
+	// release is the layout for a release page, given a tag.
+	release string
+
+	// issues is the layout for the issue tracker.
+	issues string
+
+	// issuesHost is the host of the issue tracker, if it is hosted separately
+	// from the repository.
+	issuesHost string
+
+	// compare is the layout for comparing two refs, given the older and newer
+	// refs.
+	compare string
+
+	// newIssue is the layout for creating a new issue.
+	newIssue string
+
+	// newIssueBody is the query parameter used to prefill the body of a new
+	// issue.
+	newIssueBody string
+}
+
+// forges are the supported code hosting services, keyed by name.
+var forges = map[string]forge{
+	"bitbucket": {
+		commit:  "commits/%s",
+		tree:    "src/%s",
+		release: "src/%s",
+		issues:  "issues",
+		compare: "branches/compare/%[2]s%%0D%[1]s",
+	},
+	"gitea": {
+		commit:       "commit/%s",
+		tree:         "src/commit/%s",
+		release:      "releases/tag/%s",
+		issues:       "issues",
+		compare:      "compare/%s...%s",
+		newIssue:     "issues/new",
+		newIssueBody: "body",
+	},
+	"github": {
+		commit:       "commit/%s",
+		tree:         "tree/%s",
+		release:      "releases/tag/%s",
+		issues:       "issues",
+		compare:      "compare/%s...%s",
+		newIssue:     "issues/new",
+		newIssueBody: "body",
+	},
+	"gitlab": {
+		commit:       "-/commit/%s",
+		tree:         "-/tree/%s",
+		release:      "-/releases/%s",
+		issues:       "-/issues",
+		compare:      "-/compare/%s...%s",
+		newIssue:     "-/issues/new",
+		newIssueBody: "issue[description]",
+	},
+	"sourcehut": {
+		commit:     "commit/%s",
+		tree:       "tree/%s",
+		release:    "refs/%s",
+		issuesHost: "todo.sr.ht",
+	},
+}
+
+// forgeHosts maps the hosts of well-known code hosting services to the name of
+// the service.
+var forgeHosts = map[string]string{
+	"bitbucket.org": "bitbucket",
+	"codeberg.org":  "gitea",
+	"gitea.com":     "gitea",
+	"github.com":    "github",
+	"gitlab.com":    "gitlab",
+	"git.sr.ht":     "sourcehut",
+}
+
+// CommitURL returns the URL of the page for the commit that the application
+// was built from, or nil if either the source URL or SHA is not set, or the
+// source is not hosted on a supported service. GitHub, GitLab, Gitea,
+// Bitbucket, and sourcehut are supported, as are self-hosted instances whose
+// host name starts with github., gitlab., or gitea.
+func CommitURL() *u.URL {
+	return forgeURL(Source(), "commit", SHA())
+}
+
+// TreeURL returns the URL for browsing the source code of the application, as
+// of the commit it was built from, or nil if it can not be determined. See
+// CommitURL for the supported services.
+func TreeURL() *u.URL {
+	return forgeURL(Source(), "tree", SHA())
+}
+
+// ReleaseURL returns the URL of the release page for the application version,
+// or nil if it can not be determined. See CommitURL for the supported
+// services.
+func ReleaseURL() *u.URL {
+	return forgeURL(Source(), "release", Version())
+}
+
+// IssuesURL returns the URL of the issue tracker for the application, or nil
+// if it can not be determined. See CommitURL for the supported services.
+func IssuesURL() *u.URL {
+	return forgeURL(Source(), "issues")
+}
+
+// CompareURL returns the URL of the page comparing the given older and newer
+// refs, such as two versions, or nil if it can not be determined. See
+// CommitURL for the supported services.
+func CompareURL(older, newer string) *u.URL {
+	return forgeURL(Source(), "compare", older, newer)
+}
+
+// forgeURL returns the URL of the named kind of page, for the repository with
+// the given URL, formatted using the given refs. Returns nil if the repository
+// is not hosted on a supported service, the service does not support the kind
+// of page, or any of the refs are empty.
+func forgeURL(repo *u.URL, kind string, refs ...string) *u.URL {
+	if repo == nil {
+		return nil
+	}
+
+	f, ok := forges[forgeName(repo.Hostname())]
+	if !ok {
+		return nil
+	}
+
+	args := make([]interface{}, len(refs))
+
+	for i, ref := range refs {
+		if ref == "" {
+			return nil
+		}
+
+		args[i] = ref
+	}
+
+	layout := map[string]string{
+		"commit":   f.commit,
+		"tree":     f.tree,
+		"release":  f.release,
+		"issues":   f.issues,
+		"compare":  f.compare,
+		"newIssue": f.newIssue,
+	}[kind]
+
+	// The repository URL, without any .git suffix, trailing slash, query, or
+	// fragment.
+	base := *repo
+	base.Path = strings.TrimSuffix(strings.TrimSuffix(base.Path, "/"), ".git")
+	base.RawPath, base.RawQuery, base.Fragment = "", "", ""
+
+	if kind == "issues" && f.issuesHost != "" {
+		base.Host = f.issuesHost
+
+		return &base
+	}
+
+	if layout == "" {
+		return nil
+	}
+
+	// The layout is appended as a string, rather than to the path, so that
+	// any escaping in the layout is preserved.
+	result, err := u.Parse(base.String() + "/" + fmt.Sprintf(layout, args...))
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+// forgeName returns the name of the code hosting service for the given host,
+// or an empty string if the host is not known.
+func forgeName(host string) string {
+	host = strings.ToLower(host)
+
+	if name, found := forgeHosts[host]; found {
+		return name
+	}
+
+	for _, name := range []string{"github", "gitlab", "gitea"} {
+		if strings.HasPrefix(host, name+".") {
+			return name
+		}
+	}
+
+	return ""
+}
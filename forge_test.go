@@ -0,0 +1,179 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	u "net/url"
+	"testing"
+)
+
+func TestForgeURL(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	const sha = "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6"
+
+	tests := []struct {
+		repo     string
+		kind     string
+		refs     []string
+		expected string
+	}{
+		// GitHub, including the .git suffix used in the README.
+		{
+			repo:     "https://github.com/joshdk/meta.git",
+			kind:     "commit",
+			refs:     []string{sha},
+			expected: "https://github.com/joshdk/meta/commit/" + sha,
+		},
+		{
+			repo:     "https://github.com/joshdk/meta/",
+			kind:     "tree",
+			refs:     []string{sha},
+			expected: "https://github.com/joshdk/meta/tree/" + sha,
+		},
+		{
+			repo:     "https://github.com/joshdk/meta",
+			kind:     "release",
+			refs:     []string{"v1.2.3"},
+			expected: "https://github.com/joshdk/meta/releases/tag/v1.2.3",
+		},
+		{
+			repo:     "https://github.com/joshdk/meta",
+			kind:     "issues",
+			expected: "https://github.com/joshdk/meta/issues",
+		},
+		{
+			repo:     "https://github.com/joshdk/meta",
+			kind:     "compare",
+			refs:     []string{"v1.2.2", "v1.2.3"},
+			expected: "https://github.com/joshdk/meta/compare/v1.2.2...v1.2.3",
+		},
+		{
+			repo:     "https://github.example.com/joshdk/meta",
+			kind:     "issues",
+			expected: "https://github.example.com/joshdk/meta/issues",
+		},
+
+		// GitLab, including subgroups and self-hosted instances.
+		{
+			repo:     "https://gitlab.com/joshdk/group/meta.git",
+			kind:     "commit",
+			refs:     []string{sha},
+			expected: "https://gitlab.com/joshdk/group/meta/-/commit/" + sha,
+		},
+		{
+			repo:     "https://gitlab.com/joshdk/meta",
+			kind:     "release",
+			refs:     []string{"v1.2.3"},
+			expected: "https://gitlab.com/joshdk/meta/-/releases/v1.2.3",
+		},
+		{
+			repo:     "https://gitlab.example.com:8443/joshdk/meta",
+			kind:     "compare",
+			refs:     []string{"v1.2.2", "v1.2.3"},
+			expected: "https://gitlab.example.com:8443/joshdk/meta/-/compare/v1.2.2...v1.2.3",
+		},
+
+		// Gitea, including Codeberg.
+		{
+			repo:     "https://codeberg.org/joshdk/meta",
+			kind:     "tree",
+			refs:     []string{sha},
+			expected: "https://codeberg.org/joshdk/meta/src/commit/" + sha,
+		},
+		{
+			repo:     "https://gitea.example.com/joshdk/meta",
+			kind:     "release",
+			refs:     []string{"v1.2.3"},
+			expected: "https://gitea.example.com/joshdk/meta/releases/tag/v1.2.3",
+		},
+
+		// Bitbucket, which lists the newer ref first when comparing.
+		{
+			repo:     "https://bitbucket.org/joshdk/meta.git",
+			kind:     "commit",
+			refs:     []string{sha},
+			expected: "https://bitbucket.org/joshdk/meta/commits/" + sha,
+		},
+		{
+			repo:     "https://bitbucket.org/joshdk/meta",
+			kind:     "compare",
+			refs:     []string{"v1.2.2", "v1.2.3"},
+			expected: "https://bitbucket.org/joshdk/meta/branches/compare/v1.2.3%0Dv1.2.2",
+		},
+
+		// Sourcehut, which hosts issues separately and can not compare refs.
+		{
+			repo:     "https://git.sr.ht/~joshdk/meta",
+			kind:     "commit",
+			refs:     []string{sha},
+			expected: "https://git.sr.ht/~joshdk/meta/commit/" + sha,
+		},
+		{
+			repo:     "https://git.sr.ht/~joshdk/meta",
+			kind:     "issues",
+			expected: "https://todo.sr.ht/~joshdk/meta",
+		},
+		{
+			repo: "https://git.sr.ht/~joshdk/meta",
+			kind: "compare",
+			refs: []string{"v1.2.2", "v1.2.3"},
+		},
+
+		// Unknown services, and missing refs.
+		{
+			repo: "https://example.com/demo.git",
+			kind: "commit",
+			refs: []string{sha},
+		},
+		{
+			repo: "https://github.com/joshdk/meta",
+			kind: "commit",
+			refs: []string{""},
+		},
+	}
+
+	for _, test := range tests {
+		repo, err := u.Parse(test.repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := forgeURL(repo, test.kind, test.refs...)
+
+		switch {
+		case test.expected == "" && actual != nil:
+			t.Fatalf("expected no %s url for %s but got %s", test.kind, test.repo, actual)
+		case test.expected != "" && actual == nil:
+			t.Fatalf("expected %s url for %s but got none", test.kind, test.repo)
+		case actual != nil:
+			equalString(t, test.expected, actual.String())
+		}
+	}
+}
+
+func TestForgeURLUnset(t *testing.T) {
+	t.Parallel()
+
+	// The test binary is built without a source url.
+	for _, actual := range []*u.URL{CommitURL(), TreeURL(), ReleaseURL(), IssuesURL(), CompareURL("v1.2.2", "v1.2.3")} {
+		if actual != nil {
+			t.Fatalf("expected no url but got %s", actual)
+		}
+	}
+}
+
+// TestForgeGitRemote modifies global state, and so must not run in parallel.
+func TestForgeGitRemote(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	src = "git@github.com:joshdk/meta.git"
+	sha = "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6"
+	parse()
+
+	equalString(t, "git@github.com:joshdk/meta.git", CloneURL())
+	equalString(t, "https://github.com/joshdk/meta", Source().String())
+	equalString(t, "https://github.com/joshdk/meta/commit/"+sha, CommitURL().String())
+}
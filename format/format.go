@@ -0,0 +1,168 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+// Package format renders application metadata, as returned by the public
+// functions in jdk.sh/meta, in a number of output formats. It is used by the
+// CLI framework adapters, and can be used to produce the same output for any
+// other framework.
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"jdk.sh/meta"
+)
+
+// List of supported output formats.
+const (
+	JSON = "json"
+	Text = "text"
+	YAML = "yaml"
+)
+
+// Formats is the list of supported output formats.
+var Formats = []string{Text, JSON, YAML}
+
+// field is a single named metadata value.
+type field struct {
+	key   string
+	value string
+}
+
+// fields returns the non-empty metadata values, in a stable order.
+func fields(snapshot meta.Snapshot) []field {
+	all := []field{
+		{"name", snapshot.Name},
+		{"title", snapshot.Title},
+		{"description", snapshot.Description},
+		{"version", snapshot.Version},
+		{"sha", snapshot.SHA},
+		{"date", snapshot.Date},
+		{"development", development(snapshot.Development)},
+		{"author", snapshot.Author},
+		{"author_email", snapshot.AuthorEmail},
+		{"author_url", snapshot.AuthorURL},
+		{"copyright", snapshot.Copyright},
+		{"license", snapshot.License},
+		{"license_url", snapshot.LicenseURL},
+		{"url", snapshot.URL},
+		{"docs", snapshot.Docs},
+		{"src", snapshot.Source},
+		{"note", snapshot.Note},
+		{"go", snapshot.Go},
+		{"os", snapshot.OS},
+		{"arch", snapshot.Arch},
+	}
+
+	result := make([]field, 0, len(all))
+
+	for _, f := range all {
+		if f.value != "" {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+// Write renders the application metadata to the given writer, using the
+// named output format.
+func Write(w io.Writer, format string) error {
+	return WriteSnapshot(w, format, meta.Data())
+}
+
+// WriteSnapshot renders the given metadata snapshot to the given writer, using
+// the named output format.
+func WriteSnapshot(w io.Writer, format string, snapshot meta.Snapshot) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, fields(snapshot), snapshot.Sources)
+	case Text, "":
+		return writeText(w, fields(snapshot))
+	case YAML:
+		return writeYAML(w, fields(snapshot))
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// writeJSON renders the given fields as a JSON object, along with where each
+// value came from.
+func writeJSON(w io.Writer, fields []field, sources map[string]string) error {
+	// A map cannot be used, since it would not preserve the field order.
+	var b strings.Builder
+
+	b.WriteString("{")
+
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		key, _ := json.Marshal(f.key)
+		value, _ := json.Marshal(f.value)
+		fmt.Fprintf(&b, "\n  %s: %s", key, value)
+	}
+
+	if len(sources) > 0 {
+		if len(fields) > 0 {
+			b.WriteString(",")
+		}
+
+		// Map keys are sorted when marshalled.
+		value, _ := json.MarshalIndent(sources, "  ", "  ")
+		fmt.Fprintf(&b, "\n  \"sources\": %s", value)
+	}
+
+	b.WriteString("\n}\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// writeText renders the given fields as aligned, human readable, lines.
+func writeText(w io.Writer, fields []field) error {
+	var width int
+
+	for _, f := range fields {
+		if len(f.key) > width {
+			width = len(f.key)
+		}
+	}
+
+	for _, f := range fields {
+		if _, err := fmt.Fprintf(w, "%-*s %s\n", width+1, f.key+":", f.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeYAML renders the given fields as a YAML mapping. Every value is emitted
+// as a double-quoted JSON string, which is also a valid YAML scalar.
+func writeYAML(w io.Writer, fields []field) error {
+	for _, f := range fields {
+		value, _ := json.Marshal(f.value)
+		if _, err := fmt.Fprintf(w, "%s: %s\n", f.key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// development returns "true" if the application is in development mode, or an
+// empty string otherwise.
+func development(dev bool) string {
+	if dev {
+		return "true"
+	}
+
+	return ""
+}
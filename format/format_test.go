@@ -0,0 +1,98 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	fields := []field{
+		{"name", "demo-app"},
+		{"version", "v1.2.3"},
+		{"note", `Say "hello"`},
+	}
+
+	sources := map[string]string{
+		"jdk.sh/meta.version": "buildinfo",
+		"jdk.sh/meta.name":    "ldflags",
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: JSON,
+			expected: `{
+  "name": "demo-app",
+  "version": "v1.2.3",
+  "note": "Say \"hello\"",
+  "sources": {
+    "jdk.sh/meta.name": "ldflags",
+    "jdk.sh/meta.version": "buildinfo"
+  }
+}
+`,
+		},
+		{
+			format: Text,
+			expected: `name:    demo-app
+version: v1.2.3
+note:    Say "hello"
+`,
+		},
+		{
+			format: YAML,
+			expected: `name: "demo-app"
+version: "v1.2.3"
+note: "Say \"hello\""
+`,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				buf bytes.Buffer
+				err error
+			)
+
+			switch test.format {
+			case JSON:
+				err = writeJSON(&buf, fields, sources)
+			case Text:
+				err = writeText(&buf, fields)
+			case YAML:
+				err = writeYAML(&buf, fields)
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual := buf.String(); actual != test.expected {
+				t.Fatalf("expected %q but got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWriteUnknown(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "xml"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
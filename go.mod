@@ -1,3 +1,3 @@
 module jdk.sh/meta
 
-go 1.17
+go 1.18
@@ -0,0 +1,136 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package inspect
+
+import (
+	"runtime/debug"
+	"sort"
+)
+
+// List of change kinds.
+const (
+	// KindValue is a change to the value of a jdk.sh/meta variable.
+	KindValue = "value"
+
+	// KindGo is a change to the Go version.
+	KindGo = "go"
+
+	// KindMain is a change to the main module version.
+	KindMain = "main"
+
+	// KindSetting is a change to a Go build setting.
+	KindSetting = "setting"
+
+	// KindDep is a change to a module dependency version.
+	KindDep = "dep"
+)
+
+// Change is a single difference between two binaries. An empty Old value
+// indicates an addition, and an empty New value indicates a removal.
+type Change struct {
+	Kind string `json:"kind"`
+	Key  string `json:"key"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// Diff returns every difference between the metadata of the two given
+// binaries, including jdk.sh/meta values, the Go version, build settings, and
+// module dependency versions. Changes are ordered by kind, and then by key.
+func Diff(old, new *Binary) []Change { // nolint:predeclared
+	var changes []Change
+
+	changes = append(changes, diffMaps(KindValue, old.Values, new.Values)...)
+
+	oldInfo, newInfo := old.BuildInfo, new.BuildInfo
+	if oldInfo == nil {
+		oldInfo = &debug.BuildInfo{}
+	}
+
+	if newInfo == nil {
+		newInfo = &debug.BuildInfo{}
+	}
+
+	if oldInfo.GoVersion != newInfo.GoVersion {
+		changes = append(changes, Change{KindGo, "go", oldInfo.GoVersion, newInfo.GoVersion})
+	}
+
+	if oldMain, newMain := moduleVersion(&oldInfo.Main), moduleVersion(&newInfo.Main); oldMain != newMain {
+		changes = append(changes, Change{KindMain, newInfo.Main.Path, oldMain, newMain})
+	}
+
+	changes = append(changes, diffMaps(KindSetting, settings(oldInfo), settings(newInfo))...)
+	changes = append(changes, diffMaps(KindDep, deps(oldInfo), deps(newInfo))...)
+
+	return changes
+}
+
+// diffMaps returns the differences between the two given maps, sorted by key.
+func diffMaps(kind string, old, new map[string]string) []Change { // nolint:predeclared
+	keys := make(map[string]struct{})
+
+	for key := range old {
+		keys[key] = struct{}{}
+	}
+
+	for key := range new {
+		keys[key] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	sort.Strings(sorted)
+
+	var changes []Change
+
+	for _, key := range sorted {
+		if old[key] != new[key] {
+			changes = append(changes, Change{kind, key, old[key], new[key]})
+		}
+	}
+
+	return changes
+}
+
+// settings returns the build settings from the given build information, keyed
+// by name.
+func settings(info *debug.BuildInfo) map[string]string {
+	result := make(map[string]string, len(info.Settings))
+
+	for _, setting := range info.Settings {
+		result[setting.Key] = setting.Value
+	}
+
+	return result
+}
+
+// deps returns the module dependency versions from the given build
+// information, keyed by module path.
+func deps(info *debug.BuildInfo) map[string]string {
+	result := make(map[string]string, len(info.Deps))
+
+	for _, dep := range info.Deps {
+		result[dep.Path] = moduleVersion(dep)
+	}
+
+	return result
+}
+
+// moduleVersion returns the version of the given module, including any
+// replacement.
+func moduleVersion(module *debug.Module) string {
+	if module.Replace == nil {
+		return module.Version
+	}
+
+	if module.Replace.Version == "" {
+		return module.Version + " => " + module.Replace.Path
+	}
+
+	return module.Version + " => " + module.Replace.Path + " " + module.Replace.Version
+}
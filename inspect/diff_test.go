@@ -0,0 +1,80 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package inspect
+
+import (
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	old := &Binary{
+		Values: map[string]string{
+			"jdk.sh/meta.name":    "demo-app",
+			"jdk.sh/meta.note":    "Example note",
+			"jdk.sh/meta.version": "v1.2.3",
+		},
+		BuildInfo: &debug.BuildInfo{
+			GoVersion: "go1.17.1",
+			Main:      debug.Module{Path: "example.com/demo", Version: "v1.2.3"},
+			Deps: []*debug.Module{
+				{Path: "example.com/a", Version: "v1.0.0"},
+				{Path: "example.com/b", Version: "v1.0.0"},
+			},
+			Settings: []debug.BuildSetting{
+				{Key: "CGO_ENABLED", Value: "1"},
+				{Key: "GOOS", Value: "linux"},
+			},
+		},
+	}
+
+	new := &Binary{ // nolint:predeclared
+		Values: map[string]string{
+			"jdk.sh/meta.name":    "demo-app",
+			"jdk.sh/meta.sha":     "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+			"jdk.sh/meta.version": "v1.2.4",
+		},
+		BuildInfo: &debug.BuildInfo{
+			GoVersion: "go1.17.2",
+			Main:      debug.Module{Path: "example.com/demo", Version: "v1.2.4"},
+			Deps: []*debug.Module{
+				{Path: "example.com/a", Version: "v1.0.0", Replace: &debug.Module{Path: "../a"}},
+				{Path: "example.com/c", Version: "v1.1.0"},
+			},
+			Settings: []debug.BuildSetting{
+				{Key: "CGO_ENABLED", Value: "0"},
+				{Key: "GOOS", Value: "linux"},
+			},
+		},
+	}
+
+	expected := []Change{
+		{KindValue, "jdk.sh/meta.note", "Example note", ""},
+		{KindValue, "jdk.sh/meta.sha", "", "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6"},
+		{KindValue, "jdk.sh/meta.version", "v1.2.3", "v1.2.4"},
+		{KindGo, "go", "go1.17.1", "go1.17.2"},
+		{KindMain, "example.com/demo", "v1.2.3", "v1.2.4"},
+		{KindSetting, "CGO_ENABLED", "1", "0"},
+		{KindDep, "example.com/a", "v1.0.0", "v1.0.0 => ../a"},
+		{KindDep, "example.com/b", "v1.0.0", ""},
+		{KindDep, "example.com/c", "", "v1.1.0"},
+	}
+
+	if actual := Diff(old, new); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	if actual := Diff(old, old); len(actual) != 0 {
+		t.Fatalf("expected no changes but got %v", actual)
+	}
+
+	// Binaries without any build information.
+	if actual := Diff(&Binary{}, &Binary{}); len(actual) != 0 {
+		t.Fatalf("expected no changes but got %v", actual)
+	}
+}
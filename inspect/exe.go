@@ -0,0 +1,342 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package inspect
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errNotFound is returned when a symbol or address is not present in a binary.
+var errNotFound = errors.New("not found")
+
+// exe is an executable file that was opened for reading.
+type exe interface {
+	// byteOrder returns the byte order of the executable.
+	byteOrder() binary.ByteOrder
+
+	// ptrSize returns the size of a pointer, in bytes, of the executable.
+	ptrSize() int
+
+	// symbol returns the virtual address and size, in bytes, of the named
+	// symbol.
+	symbol(name string) (uint64, uint64, error)
+
+	// offset returns the file offset of the given virtual address, along with
+	// the number of bytes available at that offset. An offset of -1 indicates
+	// that the address is zero-filled, and not stored in the file.
+	offset(addr uint64) (int64, uint64, error)
+}
+
+// openExe opens the named file as an ELF, Mach-O, or PE executable, using the
+// given os.OpenFile flag. The returned file must be closed by the caller.
+func openExe(path string, flag int) (exe, *os.File, error) {
+	file, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sniff the file magic to determine the executable format.
+	magic := make([]byte, 4) // nolint:gomnd
+	if _, err := file.ReadAt(magic, 0); err != nil {
+		file.Close()
+
+		return nil, nil, fmt.Errorf("%s: unrecognized executable format", path)
+	}
+
+	var result exe
+
+	switch {
+	case string(magic) == elf.ELFMAG:
+		result, err = openELF(file)
+	case string(magic[:2]) == "MZ":
+		result, err = openPE(file)
+	default:
+		result, err = openMachO(file)
+	}
+
+	if err != nil {
+		file.Close()
+
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return result, file, nil
+}
+
+// readAt reads size bytes from the given virtual address.
+func readAt(e exe, file io.ReaderAt, addr, size uint64) ([]byte, error) {
+	off, available, err := e.offset(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > available {
+		return nil, fmt.Errorf("address %#x: %w", addr, io.ErrUnexpectedEOF)
+	}
+
+	buf := make([]byte, size)
+
+	// A zero-filled address is not stored in the file at all.
+	if off < 0 {
+		return buf, nil
+	}
+
+	if _, err := file.ReadAt(buf, off); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// readPtr reads a single pointer sized value from the given virtual address.
+func readPtr(e exe, file io.ReaderAt, addr uint64) (uint64, error) {
+	buf, err := readAt(e, file, addr, uint64(e.ptrSize()))
+	if err != nil {
+		return 0, err
+	}
+
+	if e.ptrSize() == 4 { // nolint:gomnd
+		return uint64(e.byteOrder().Uint32(buf)), nil
+	}
+
+	return e.byteOrder().Uint64(buf), nil
+}
+
+// elfExe is an ELF executable.
+type elfExe struct {
+	*elf.File
+}
+
+func openELF(r io.ReaderAt) (exe, error) {
+	file, err := elf.NewFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &elfExe{file}, nil
+}
+
+func (e *elfExe) byteOrder() binary.ByteOrder {
+	return e.ByteOrder
+}
+
+func (e *elfExe) ptrSize() int {
+	if e.Class == elf.ELFCLASS32 {
+		return 4 // nolint:gomnd
+	}
+
+	return 8 // nolint:gomnd
+}
+
+func (e *elfExe) symbol(name string) (uint64, uint64, error) {
+	symbols, err := e.Symbols()
+	if errors.Is(err, elf.ErrNoSymbols) {
+		return 0, 0, errNotFound
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	for _, symbol := range symbols {
+		if symbol.Name == name {
+			return symbol.Value, symbol.Size, nil
+		}
+	}
+
+	return 0, 0, errNotFound
+}
+
+func (e *elfExe) offset(addr uint64) (int64, uint64, error) {
+	for _, section := range e.Sections {
+		if section.Addr == 0 || addr < section.Addr || addr >= section.Addr+section.Size {
+			continue
+		}
+
+		if section.Type == elf.SHT_NOBITS {
+			return -1, section.Addr + section.Size - addr, nil
+		}
+
+		return int64(section.Offset + addr - section.Addr), section.Addr + section.Size - addr, nil
+	}
+
+	return 0, 0, fmt.Errorf("address %#x: %w", addr, errNotFound)
+}
+
+// machoExe is a Mach-O executable.
+type machoExe struct {
+	*macho.File
+}
+
+func openMachO(r io.ReaderAt) (exe, error) {
+	file, err := macho.NewFile(r)
+	if err != nil {
+		return nil, errors.New("unrecognized executable format")
+	}
+
+	return &machoExe{file}, nil
+}
+
+func (e *machoExe) byteOrder() binary.ByteOrder {
+	return e.ByteOrder
+}
+
+func (e *machoExe) ptrSize() int {
+	if e.Magic == macho.Magic32 {
+		return 4 // nolint:gomnd
+	}
+
+	return 8 // nolint:gomnd
+}
+
+func (e *machoExe) symbol(name string) (uint64, uint64, error) {
+	if e.Symtab == nil {
+		return 0, 0, errNotFound
+	}
+
+	for _, symbol := range e.Symtab.Syms {
+		// Symbols may or may not have a leading underscore, depending on
+		// whether the binary was linked internally or externally.
+		if symbol.Name != name && symbol.Name != "_"+name {
+			continue
+		}
+
+		// Mach-O symbols have no size, so it's inferred from the address of
+		// the next symbol in the same section.
+		size := uint64(0)
+
+		for _, other := range e.Symtab.Syms {
+			if other.Sect == symbol.Sect && other.Value > symbol.Value && (size == 0 || other.Value-symbol.Value < size) {
+				size = other.Value - symbol.Value
+			}
+		}
+
+		return symbol.Value, size, nil
+	}
+
+	return 0, 0, errNotFound
+}
+
+func (e *machoExe) offset(addr uint64) (int64, uint64, error) {
+	// Section types that are zero-filled, and not stored in the file.
+	const (
+		sectionTypeMask = 0xff
+		zeroFill        = 0x1
+		gbZeroFill      = 0xc
+		threadZeroFill  = 0x12
+	)
+
+	for _, section := range e.Sections {
+		if addr < section.Addr || addr >= section.Addr+section.Size {
+			continue
+		}
+
+		switch section.Flags & sectionTypeMask {
+		case zeroFill, gbZeroFill, threadZeroFill:
+			return -1, section.Addr + section.Size - addr, nil
+		}
+
+		return int64(uint64(section.Offset) + addr - section.Addr), section.Addr + section.Size - addr, nil
+	}
+
+	return 0, 0, fmt.Errorf("address %#x: %w", addr, errNotFound)
+}
+
+// peExe is a PE executable.
+type peExe struct {
+	*pe.File
+}
+
+func openPE(r io.ReaderAt) (exe, error) {
+	file, err := pe.NewFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &peExe{file}, nil
+}
+
+func (e *peExe) byteOrder() binary.ByteOrder {
+	return binary.LittleEndian
+}
+
+func (e *peExe) ptrSize() int {
+	if _, ok := e.OptionalHeader.(*pe.OptionalHeader32); ok {
+		return 4 // nolint:gomnd
+	}
+
+	return 8 // nolint:gomnd
+}
+
+// imageBase returns the preferred virtual address of the loaded image.
+func (e *peExe) imageBase() uint64 {
+	switch header := e.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		return uint64(header.ImageBase)
+	case *pe.OptionalHeader64:
+		return header.ImageBase
+	default:
+		return 0
+	}
+}
+
+func (e *peExe) symbol(name string) (uint64, uint64, error) {
+	for _, symbol := range e.Symbols {
+		if symbol.Name != name {
+			continue
+		}
+
+		// Symbol values are relative to the start of their section.
+		if symbol.SectionNumber <= 0 || int(symbol.SectionNumber) > len(e.Sections) {
+			return 0, 0, errNotFound
+		}
+
+		section := e.Sections[symbol.SectionNumber-1]
+
+		// PE symbols have no size, so it's inferred from the address of the
+		// next symbol in the same section.
+		size := uint64(0)
+
+		for _, other := range e.Symbols {
+			if other.SectionNumber != symbol.SectionNumber || other.Value <= symbol.Value {
+				continue
+			}
+
+			if distance := uint64(other.Value - symbol.Value); size == 0 || distance < size {
+				size = distance
+			}
+		}
+
+		return e.imageBase() + uint64(section.VirtualAddress) + uint64(symbol.Value), size, nil
+	}
+
+	return 0, 0, errNotFound
+}
+
+func (e *peExe) offset(addr uint64) (int64, uint64, error) {
+	for _, section := range e.Sections {
+		start := e.imageBase() + uint64(section.VirtualAddress)
+		end := start + uint64(section.VirtualSize)
+
+		if addr < start || addr >= end {
+			continue
+		}
+
+		// The tail of a section that is larger in memory than on disk is
+		// zero-filled.
+		if addr-start >= uint64(section.Size) {
+			return -1, end - addr, nil
+		}
+
+		return int64(uint64(section.Offset) + addr - start), uint64(section.Size) - (addr - start), nil
+	}
+
+	return 0, 0, fmt.Errorf("address %#x: %w", addr, errNotFound)
+}
@@ -0,0 +1,231 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+// Package inspect reads the application metadata out of a compiled Go binary,
+// without executing it. ELF, Mach-O, and PE binaries are supported.
+package inspect
+
+import (
+	"debug/buildinfo"
+	"errors"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"unicode/utf8"
+
+	"jdk.sh/meta"
+)
+
+// maxValueLength is the largest string value that will be read out of a
+// binary, in order to guard against corrupt or unexpected data.
+const maxValueLength = 1 << 16
+
+// Binary is the metadata that was read out of a compiled binary.
+type Binary struct {
+	// Path is the file path of the binary.
+	Path string `json:"path"`
+
+	// Values is the raw value of every variable that was set, keyed by
+	// variable name.
+	Values map[string]string `json:"values"`
+
+	// Metadata contains the values that each public function in jdk.sh/meta
+	// would return, were the binary executed, including any values inferred
+	// from the Go build information. The Arch, Go, and OS fields are also
+	// populated from the Go build information. Environment variable
+	// overrides are not applied.
+	Metadata meta.Snapshot `json:"metadata"`
+
+	// Err is the reason that the binary would panic when executed, due to a
+	// malformed value, or nil.
+	Err error `json:"-"`
+
+	// BuildInfo is the Go build information embedded in the binary, or nil if
+	// it was not present.
+	BuildInfo *debug.BuildInfo `json:"build_info,omitempty"`
+}
+
+// Open reads the metadata out of the named binary. Values are located using
+// the symbol table, and if the binary was stripped, the -ldflags setting
+// recorded in the Go build information is used instead.
+func Open(path string) (*Binary, error) {
+	exe, file, err := openExe(path, os.O_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+
+	for _, name := range meta.Variables() {
+		value, err := readString(exe, file, name)
+
+		switch {
+		case errors.Is(err, errNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		case value != "":
+			values[name] = value
+		}
+	}
+
+	// Build information is only present in binaries built using modules.
+	info, err := buildinfo.Read(file)
+	if err != nil {
+		info = nil
+	}
+
+	// Stripped binaries have no symbol table, but the values can still be
+	// recovered from the linker flags.
+	if len(values) == 0 && info != nil {
+		values = ldflagsValues(info)
+	}
+
+	binary := &Binary{
+		Path:      path,
+		Values:    values,
+		BuildInfo: info,
+	}
+
+	// Values that were not set fall back to the build information, as they
+	// would when the binary is executed.
+	binary.Metadata, binary.Err = meta.ParseWithBuildInfo(values, info)
+
+	if info != nil {
+		binary.Metadata.Go = info.GoVersion
+		binary.Metadata.OS = setting(info, "GOOS")
+		binary.Metadata.Arch = setting(info, "GOARCH")
+	}
+
+	return binary, nil
+}
+
+// readString reads the value of the named string variable.
+func readString(e exe, file *os.File, name string) (string, error) {
+	_, ptr, length, err := readHeader(e, file, name)
+	if err != nil {
+		return "", err
+	}
+
+	if length == 0 || length > maxValueLength {
+		return "", nil
+	}
+
+	data, err := readAt(e, file, ptr, length)
+	if err != nil {
+		return "", err
+	}
+
+	if !utf8.Valid(data) {
+		return "", nil
+	}
+
+	return string(data), nil
+}
+
+// readHeader reads the header of the named string variable, which is a
+// pointer to the string data, followed by the string length. The address of
+// the header itself is also returned.
+func readHeader(e exe, file io.ReaderAt, name string) (uint64, uint64, uint64, error) {
+	addr, _, err := e.symbol(name)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	ptr, err := readPtr(e, file, addr)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	length, err := readPtr(e, file, addr+uint64(e.ptrSize()))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return addr, ptr, length, nil
+}
+
+// ldflagsValues extracts any -X values for known variables from the -ldflags
+// setting in the given build information.
+func ldflagsValues(info *debug.BuildInfo) map[string]string {
+	values := make(map[string]string)
+	known := make(map[string]bool)
+
+	for _, name := range meta.Variables() {
+		known[name] = true
+	}
+
+	args := splitQuoted(setting(info, "-ldflags"))
+
+	for i, arg := range args {
+		var definition string
+
+		switch {
+		case arg == "-X" && i+1 < len(args):
+			definition = args[i+1]
+		case strings.HasPrefix(arg, "-X="):
+			definition = strings.TrimPrefix(arg, "-X=")
+		default:
+			continue
+		}
+
+		if name, value, found := strings.Cut(definition, "="); found && known[name] {
+			values[name] = value
+		}
+	}
+
+	return values
+}
+
+// setting returns the value of the named build setting, or an empty string.
+func setting(info *debug.BuildInfo, key string) string {
+	for _, s := range info.Settings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+
+	return ""
+}
+
+// splitQuoted splits the given string on whitespace, while respecting single
+// and double quoted sections, in the same manner as the go command does when
+// parsing flag values.
+func splitQuoted(s string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		quote   rune
+		inArg   bool
+	)
+
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
@@ -0,0 +1,292 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildDemo builds the demo application for the given platform, along with a
+// custom set of ldflags, and returns the path to the resulting binary.
+func buildDemo(t *testing.T, goos, goarch string, values map[string]string, extra ...string) string {
+	t.Helper()
+
+	output := filepath.Join(t.TempDir(), "demo")
+
+	ldflags := extra
+	for key, value := range values {
+		ldflags = append(ldflags, fmt.Sprintf(`-X '%s=%s'`, key, value))
+	}
+
+	cmd := exec.Command("go", "build", "-o", output, "-ldflags", strings.Join(ldflags, " "), "./testdata/demo") // nolint:gosec,lll
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "GOOS="+goos, "GOARCH="+goarch)
+
+	if combined, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v: %s", err, combined)
+	}
+
+	return output
+}
+
+func TestOpen(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	values := map[string]string{
+		"jdk.sh/meta.author":  "Jane Doe <jdoe@example.com>",
+		"jdk.sh/meta.date":    "Fri, 23 Aug 2019 11:00:00 -0700",
+		"jdk.sh/meta.name":    "demo app",
+		"jdk.sh/meta.sha":     "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+		"jdk.sh/meta.src":     "https://example.com/demo.git",
+		"jdk.sh/meta.version": "v1.2.3-rc.456",
+	}
+
+	tests := []struct {
+		goos   string
+		goarch string
+		values map[string]string
+		extra  []string
+		fails  bool
+	}{
+		{
+			goos:   "linux",
+			goarch: "amd64",
+			values: values,
+		},
+		{
+			goos:   "linux",
+			goarch: "386",
+			values: values,
+		},
+		{
+			goos:   "linux",
+			goarch: "amd64",
+		},
+		{
+			// Stripped binaries use the recorded ldflags instead.
+			goos:   "linux",
+			goarch: "amd64",
+			values: values,
+			extra:  []string{"-s", "-w"},
+		},
+		{
+			goos:   "darwin",
+			goarch: "arm64",
+			values: values,
+		},
+		{
+			goos:   "windows",
+			goarch: "amd64",
+			values: values,
+		},
+		{
+			goos:   "windows",
+			goarch: "386",
+			values: values,
+		},
+		{
+			// The binary would panic when executed.
+			goos:   "linux",
+			goarch: "amd64",
+			values: map[string]string{"jdk.sh/meta.sha": "HEAD"},
+			fails:  true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			path := buildDemo(t, test.goos, test.goarch, test.values, test.extra...)
+
+			binary, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(test.values) == 0 {
+				test.values = map[string]string{}
+			}
+
+			if !reflect.DeepEqual(test.values, binary.Values) {
+				t.Fatalf("expected %v but got %v", test.values, binary.Values)
+			}
+
+			switch {
+			case binary.Err == nil && test.fails:
+				t.Fatal("expected a parse error")
+			case binary.Err != nil && !test.fails:
+				t.Fatalf("did not expect a parse error but got %v", binary.Err)
+			case binary.BuildInfo == nil:
+				t.Fatal("expected build info")
+			case binary.Metadata.OS != test.goos:
+				t.Fatalf("expected %q but got %q", test.goos, binary.Metadata.OS)
+			case binary.Metadata.Arch != test.goarch:
+				t.Fatalf("expected %q but got %q", test.goarch, binary.Metadata.Arch)
+			case !test.fails && binary.Metadata.Name != test.values["jdk.sh/meta.name"]:
+				t.Fatalf("expected %q but got %q", test.values["jdk.sh/meta.name"], binary.Metadata.Name)
+			}
+		})
+	}
+}
+
+// buildVCSDemo builds the demo application as the main module of a new git
+// repository, so that the Go toolchain records the VCS revision and commit time
+// in the build information, and returns the path to the resulting binary
+// along with the commit SHA.
+func buildVCSDemo(t *testing.T) (string, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := os.ReadFile("testdata/demo/main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	gomod := "module example.com/demo\n\ngo 1.18\n\nrequire jdk.sh/meta v0.0.0\n\nreplace jdk.sh/meta => " + root + "\n"
+
+	for name, data := range map[string]string{"go.mod": gomod, "main.go": string(source)} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run := func(name string, args ...string) string {
+		cmd := exec.Command(name, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"CGO_ENABLED=0",
+			"GOWORK=off",
+			"GIT_AUTHOR_NAME=Jane Doe",
+			"GIT_AUTHOR_EMAIL=jdoe@example.com",
+			"GIT_AUTHOR_DATE=2019-08-23T18:00:00Z",
+			"GIT_COMMITTER_NAME=Jane Doe",
+			"GIT_COMMITTER_EMAIL=jdoe@example.com",
+			"GIT_COMMITTER_DATE=2019-08-23T18:00:00Z",
+		)
+
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%s %v: %v", name, args, err)
+		}
+
+		return strings.TrimSpace(string(output))
+	}
+
+	run("git", "init", "--quiet")
+	run("git", "add", "go.mod", "main.go")
+	run("git", "commit", "--quiet", "--message", "Initial commit")
+	run("go", "build", "-buildvcs=true", "-o", "demo", ".")
+
+	return filepath.Join(dir, "demo"), run("git", "rev-parse", "HEAD")
+}
+
+func TestOpenBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	path, sha := buildVCSDemo(t)
+
+	binary, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No values were set using ldflags, but the SHA and date are inferred
+	// from the build information, just as they are when the binary runs.
+	if len(binary.Values) != 0 {
+		t.Fatalf("expected no values but got %v", binary.Values)
+	}
+
+	if binary.Metadata.SHA != sha || binary.Metadata.Date != "2019-08-23T18:00:00Z" {
+		t.Fatalf("unexpected metadata %+v", binary.Metadata)
+	}
+
+	for _, name := range []string{"jdk.sh/meta.date", "jdk.sh/meta.sha"} {
+		if source := binary.Metadata.Sources[name]; source != "buildinfo" {
+			t.Fatalf("expected %s to come from buildinfo but got %q", name, source)
+		}
+	}
+
+	output, err := exec.Command(path).Output() // nolint:gosec
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"SHA:" + sha + " ", "Version:" + binary.Metadata.Version + " "} {
+		if !strings.Contains(string(output), expected) {
+			t.Fatalf("expected output to contain %q but got %q", expected, output)
+		}
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "invalid")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho hello\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(path); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if _, err := Open(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestSplitQuoted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input: "",
+		},
+		{
+			input:    "-s -w",
+			expected: []string{"-s", "-w"},
+		},
+		{
+			input:    `-X 'jdk.sh/meta.name=demo app'  -X "jdk.sh/meta.note=it's"`,
+			expected: []string{"-X", "jdk.sh/meta.name=demo app", "-X", "jdk.sh/meta.note=it's"},
+		},
+		{
+			input:    `-X=jdk.sh/meta.name=demo ''`,
+			expected: []string{"-X=jdk.sh/meta.name=demo", ""},
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			if actual := splitQuoted(test.input); !reflect.DeepEqual(test.expected, actual) {
+				t.Fatalf("expected %q but got %q", test.expected, actual)
+			}
+		})
+	}
+}
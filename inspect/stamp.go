@@ -0,0 +1,135 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"jdk.sh/meta"
+)
+
+// write is a single pending write to a binary.
+type write struct {
+	offset int64
+	data   []byte
+}
+
+// Stamp rewrites the values of the given variables, keyed by variable name,
+// in the named binary, without rebuilding it. Every variable must have had
+// space reserved for it when the binary was built, typically by using a
+// placeholder value from meta.Reserve, and the new value must fit within that
+// space. Values are validated in the same manner as is done when the
+// application starts. A binary can be stamped any number of times.
+//
+// No changes are made to the binary if any value can not be stamped. Note
+// that stamping a binary will invalidate any code signature.
+func Stamp(path string, values map[string]string) error {
+	// Validate every value up front, as the binary would otherwise panic.
+	if _, err := meta.Parse(values); err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	for _, name := range meta.Variables() {
+		known[name] = true
+	}
+
+	names := make([]string, 0, len(values))
+
+	for name := range values {
+		if !known[name] {
+			return fmt.Errorf("unknown variable %s", name)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	exe, file, err := openExe(path, os.O_RDWR)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Plan every write before making any, so that a binary is never left
+	// partially stamped.
+	var writes []write
+
+	for _, name := range names {
+		planned, err := planStamp(exe, file, name, values[name])
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		writes = append(writes, planned...)
+	}
+
+	for _, w := range writes {
+		if _, err := file.WriteAt(w.data, w.offset); err != nil {
+			return err
+		}
+	}
+
+	return file.Close()
+}
+
+// planStamp returns the writes needed to stamp the given value for the named
+// variable.
+func planStamp(e exe, file io.ReaderAt, name, value string) ([]write, error) {
+	addr, ptr, _, err := readHeader(e, file, name)
+	if errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("variable %s is not present", name)
+	} else if err != nil {
+		return nil, err
+	}
+
+	// The linker stores the value of each -X flag in a dedicated symbol, the
+	// size of which is the amount of space reserved for the value.
+	reservedAddr, reserved, err := e.symbol(name + ".str")
+	if err != nil || reservedAddr != ptr || reserved == 0 {
+		return nil, fmt.Errorf("no space was reserved for variable %s, build with -X '%s=%s'",
+			name, name, meta.Reserve(len(value)))
+	}
+
+	if uint64(len(value)) > reserved {
+		return nil, fmt.Errorf("value for variable %s is %d bytes, but only %d bytes were reserved",
+			name, len(value), reserved)
+	}
+
+	dataOffset, available, err := e.offset(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	headerOffset, _, err := e.offset(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataOffset < 0 || headerOffset < 0 || available < reserved {
+		return nil, fmt.Errorf("variable %s is not stored in the binary", name)
+	}
+
+	// The unused remainder of the reserved space is zeroed.
+	data := make([]byte, reserved)
+	copy(data, value)
+
+	length := make([]byte, e.ptrSize())
+	if e.ptrSize() == 4 { // nolint:gomnd
+		e.byteOrder().PutUint32(length, uint32(len(value)))
+	} else {
+		e.byteOrder().PutUint64(length, uint64(len(value)))
+	}
+
+	return []write{
+		{offset: dataOffset, data: data},
+		{offset: headerOffset + int64(e.ptrSize()), data: length},
+	}, nil
+}
@@ -0,0 +1,182 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package inspect
+
+import (
+	"fmt"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"jdk.sh/meta"
+)
+
+func TestStamp(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	reserved := map[string]string{
+		"jdk.sh/meta.name":    "demo-app",
+		"jdk.sh/meta.note":    meta.Reserve(64),
+		"jdk.sh/meta.src":     meta.Reserve(64),
+		"jdk.sh/meta.version": meta.Reserve(32),
+	}
+
+	tests := []struct {
+		goos     string
+		goarch   string
+		stamps   []map[string]string
+		expected map[string]string
+		fails    bool
+	}{
+		{
+			goos:   "linux",
+			goarch: "amd64",
+			stamps: []map[string]string{
+				{
+					"jdk.sh/meta.note":    "Promoted to staging",
+					"jdk.sh/meta.version": "v1.2.3-rc.1",
+				},
+				{
+					// A binary can be stamped more than once.
+					"jdk.sh/meta.note":    "Promoted to production",
+					"jdk.sh/meta.src":     "https://example.com/demo.git",
+					"jdk.sh/meta.version": "v1.2.3",
+				},
+			},
+			expected: map[string]string{
+				"jdk.sh/meta.name":    "demo-app",
+				"jdk.sh/meta.note":    "Promoted to production",
+				"jdk.sh/meta.src":     "https://example.com/demo.git",
+				"jdk.sh/meta.version": "v1.2.3",
+			},
+		},
+		{
+			goos:   "linux",
+			goarch: "386",
+			stamps: []map[string]string{
+				{"jdk.sh/meta.version": "v1.2.3"},
+			},
+			expected: map[string]string{
+				"jdk.sh/meta.name":    "demo-app",
+				"jdk.sh/meta.note":    meta.Reserve(64),
+				"jdk.sh/meta.src":     meta.Reserve(64),
+				"jdk.sh/meta.version": "v1.2.3",
+			},
+		},
+		{
+			goos:   "darwin",
+			goarch: "arm64",
+			stamps: []map[string]string{
+				{"jdk.sh/meta.version": "v1.2.3"},
+			},
+			expected: map[string]string{
+				"jdk.sh/meta.name":    "demo-app",
+				"jdk.sh/meta.note":    meta.Reserve(64),
+				"jdk.sh/meta.src":     meta.Reserve(64),
+				"jdk.sh/meta.version": "v1.2.3",
+			},
+		},
+		{
+			goos:   "windows",
+			goarch: "amd64",
+			stamps: []map[string]string{
+				{"jdk.sh/meta.version": "v1.2.3"},
+			},
+			expected: map[string]string{
+				"jdk.sh/meta.name":    "demo-app",
+				"jdk.sh/meta.note":    meta.Reserve(64),
+				"jdk.sh/meta.src":     meta.Reserve(64),
+				"jdk.sh/meta.version": "v1.2.3",
+			},
+		},
+		{
+			// Value does not fit.
+			goos:   "linux",
+			goarch: "amd64",
+			stamps: []map[string]string{
+				{"jdk.sh/meta.version": "v1.2.3-" + strings.Repeat("x", 32)},
+			},
+			fails: true,
+		},
+		{
+			// Value is malformed.
+			goos:   "linux",
+			goarch: "amd64",
+			stamps: []map[string]string{
+				{"jdk.sh/meta.src": "example.com/demo.git"},
+			},
+			fails: true,
+		},
+		{
+			// Variable had no space reserved.
+			goos:   "linux",
+			goarch: "amd64",
+			stamps: []map[string]string{
+				{"jdk.sh/meta.title": "Demo"},
+			},
+			fails: true,
+		},
+		{
+			// Variable does not exist.
+			goos:   "linux",
+			goarch: "amd64",
+			stamps: []map[string]string{
+				{"jdk.sh/meta.lisense": "MIT"},
+			},
+			fails: true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			path := buildDemo(t, test.goos, test.goarch, reserved)
+
+			for _, values := range test.stamps {
+				err := Stamp(path, values)
+
+				switch {
+				case err != nil && !test.fails:
+					t.Fatalf("expected success but got %v", err)
+				case err == nil && test.fails:
+					t.Fatal("expected failure but got success")
+				}
+			}
+
+			if test.fails {
+				test.expected = reserved
+			}
+
+			binary, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expected, binary.Values) {
+				t.Fatalf("expected %v but got %v", test.expected, binary.Values)
+			}
+
+			// Execute the stamped binary, if possible, to verify that the
+			// values are seen at runtime.
+			if test.goos != runtime.GOOS || test.goarch != runtime.GOARCH {
+				return
+			}
+
+			output, err := exec.Command(path).Output() // nolint:gosec
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if expected := "Version:" + binary.Metadata.Version + " "; !strings.Contains(string(output), expected) {
+				t.Fatalf("expected output to contain %q but got %q", expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+// Command demo is a minimal application, that references all of the
+// application metadata, for use as a test fixture.
+package main
+
+import (
+	"fmt"
+
+	"jdk.sh/meta"
+)
+
+func main() {
+	fmt.Printf("%+v\n", meta.Data())
+}
@@ -36,11 +36,13 @@ type info struct {
 	Name              string
 	Note              string
 	OS                string
+	Render            string
 	SHA               string
 	ShortSHA          string
 	Source            *u.URL
 	Title             string
 	URL               *u.URL
+	UserAgent         string
 	Version           string
 	VersionBuild      string
 	VersionMajor      string
@@ -71,11 +73,13 @@ func TestJSON(t *testing.T) {
 		Name:              Name(),
 		Note:              Note(),
 		OS:                OS(),
+		Render:            mustRender(`{{.Name}} {{short .SHA}} {{(semver).Major}} {{date "2006"}}`),
 		SHA:               SHA(),
 		ShortSHA:          ShortSHA(),
 		Source:            Source(),
 		Title:             Title(),
 		URL:               URL(),
+		UserAgent:         UserAgent(ShortSHA()),
 		Version:           Version(),
 		VersionBuild:      VersionBuild(),
 		VersionMajor:      VersionMajor(),
@@ -89,6 +93,16 @@ func TestJSON(t *testing.T) {
 	}
 }
 
+// mustRender renders the given template text, and panics on failure.
+func mustRender(text string) string {
+	rendered, err := Render(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return rendered
+}
+
 // execTestJSON executes the specially crafted test TestJSON, by constructing a
 // go test command line along with a custom set of ldflags. This causes the
 // executed TestJSON test to react in a manner identical to a normal main()
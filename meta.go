@@ -29,9 +29,34 @@ package meta
 import (
 	u "net/url"
 	"runtime"
+	"runtime/debug"
 	"time"
 )
 
+func init() { // nolint:gochecknoinits
+	if info, ok := debug.ReadBuildInfo(); ok {
+		buildInfo = info
+		inferFallbacks(info)
+	}
+
+	parse()
+}
+
+// parse validates and converts the raw value of every variable, including any
+// environment variable overrides. Any malformed value causes a panic.
+func parse() {
+	loadOverrides()
+
+	raw := make(map[string]string, len(fields))
+	sources := make(map[string]string, len(fields))
+
+	for _, f := range fields {
+		raw[f.path], sources[f.path] = f.resolve()
+	}
+
+	global = newMetadata(raw, sources)
+}
+
 // Arch is the architecture target that the application is running on.
 func Arch() string {
 	return runtime.GOARCH
@@ -49,20 +74,18 @@ func Arch() string {
 //   -ldflags "-X 'jdk.sh/meta.author=Jane Doe <jdoe@example.com>'"
 var author string
 
-var authorParsed, authorEmailParsed = mustAuthor("jdk.sh/meta.author", author)
-
 // Author is the name of the application author.
 func Author() string {
-	return authorParsed
+	return global.author
 }
 
 // AuthorEmail is the email address for the application author.
 func AuthorEmail() string {
-	return authorEmailParsed
+	return global.authorEmail
 }
 
 // author_url is a URL for the application author. Typically links to the
-// author's personal homepage or Github profile.
+// author's personal homepage or GitHub profile.
 //
 // Variable name:
 //   jdk.sh/meta.author_url
@@ -71,14 +94,12 @@ func AuthorEmail() string {
 //   -ldflags "-X 'jdk.sh/meta.author_url=https://example.com/profile'"
 var author_url string
 
-var authorURLParsed = mustURL("jdk.sh/meta.author_url", author_url)
-
 // AuthorURL is the homepage URL for the application author.
 func AuthorURL() *u.URL {
-	return authorURLParsed
+	return global.authorURL
 }
 
-// copyright is the copyright for the application. Typically the name if the
+// copyright is the copyright for the application. Typically the name of the
 // author or organization, sometimes prefixed with a year or year range.
 //
 // Variable name:
@@ -92,7 +113,7 @@ var copyright string
 
 // Copyright is the copyright for the application.
 func Copyright() string {
-	return copyright
+	return global.copyright
 }
 
 // date is the time that the application was built. Supports several common
@@ -110,21 +131,21 @@ func Copyright() string {
 //   -ldflags "-X 'jdk.sh/meta.date=2019-08-23T18:00:00Z'"
 var date string
 
-var dateParsed = mustTime("jdk.sh/meta.date", date)
-
-// Date is the time at which the application was built.
+// Date is the time at which the application was built. If not set, the time of
+// the VCS commit recorded by the Go toolchain is used instead.
 func Date() *time.Time {
-	return dateParsed
+	return global.date
 }
 
 // DateFormat is the time at which the application was built, formatted using
 // the given layout.
 func DateFormat(layout string) string {
-	if dateParsed == nil {
+	date := global.date
+	if date == nil {
 		return ""
 	}
 
-	return dateParsed.Format(layout)
+	return date.Format(layout)
 }
 
 // desc is a description for the application. Typically a longer statement
@@ -139,7 +160,7 @@ var desc string
 
 // Description is the description of the application.
 func Description() string {
-	return desc
+	return global.desc
 }
 
 // dev is the development status for the application. An application in
@@ -153,11 +174,9 @@ func Description() string {
 //   -ldflags "-X 'jdk.sh/meta.dev=true'"
 var dev string
 
-var devParsed = mustBool("jdk.sh/meta.dev", dev)
-
 // Development is the development status for the application.
 func Development() bool {
-	return devParsed
+	return global.dev
 }
 
 // docs is a URL for application documentation. Typically links to a page where
@@ -168,13 +187,16 @@ func Development() bool {
 //
 // Examples:
 //   -ldflags "-X 'jdk.sh/meta.docs=https://example.com/demo/README.md'"
+//   -ldflags "-X 'jdk.sh/meta.docs=https://example.com/docs/{major}.{minor}/'"
 var docs string
 
-var docsParsed = mustURL("jdk.sh/meta.docs", docs)
-
-// Docs is the documentation URL for the application.
+// Docs is the documentation URL for the application. The version placeholders
+// {version}, {major}, {minor}, {patch}, {sha}, and {short_sha} are replaced
+// with the application version and SHA. Returns nil if any placeholder can not
+// be replaced, because the version or SHA is not set. See DocsURLFor for
+// linking to a specific page.
 func Docs() *u.URL {
-	return docsParsed
+	return global.docs
 }
 
 // Go is the version of the Go runtime that the application is running on.
@@ -182,9 +204,9 @@ func Go() string {
 	return runtime.Version()
 }
 
-// license is the license identifier for the application. Should not the full
-// license body, but one of the identifiers from https://spdx.org/licenses, so
-// that the type of license can be easily determined.
+// license is the license identifier for the application. Should not be the
+// full license body, but one of the identifiers from https://spdx.org/licenses,
+// so that the type of license can be easily determined.
 //
 // Variable name:
 //   jdk.sh/meta.license
@@ -197,7 +219,7 @@ var license string
 
 // License is the license identifier for the application.
 func License() string {
-	return license
+	return global.license
 }
 
 // license_url is a URL for the application license. Typically links to a page
@@ -208,13 +230,13 @@ func License() string {
 //
 // Examples:
 //   -ldflags "-X 'jdk.sh/meta.license_url=https://example.com/demo/LICENSE.txt'"
+//   -ldflags "-X 'jdk.sh/meta.license_url=https://example.com/demo/blob/{sha}/LICENSE.txt'"
 var license_url string
 
-var licenseURLParsed = mustURL("jdk.sh/meta.license_url", license_url)
-
-// LicenseURL is the license URL for the application.
+// LicenseURL is the license URL for the application. Version placeholders are
+// replaced in the same manner as for Docs.
 func LicenseURL() *u.URL {
-	return licenseURLParsed
+	return global.licenseURL
 }
 
 // name is the name of the application. Typically named the same as the binary,
@@ -229,7 +251,7 @@ var name string
 
 // Name is the name of the application.
 func Name() string {
-	return name
+	return global.name
 }
 
 // note is an arbitrary message for the application. Can be used to store a
@@ -245,7 +267,7 @@ var note string
 
 // Note is an arbitrary message for the application.
 func Note() string {
-	return note
+	return global.note
 }
 
 // OS is the operating system target that the application is running on.
@@ -264,20 +286,15 @@ func OS() string {
 //   -ldflags "-X 'jdk.sh/meta.sha=$(git rev-parse HEAD)'"
 var sha string
 
-var shaParsed = mustSHA("jdk.sh/meta.sha", sha)
-
-// SHA is the git SHA used to build the application.
+// SHA is the git SHA used to build the application. If not set, the VCS
+// revision recorded by the Go toolchain is used instead.
 func SHA() string {
-	return shaParsed
+	return global.sha
 }
 
 // ShortSHA is the git "short" SHA used to build the application.
 func ShortSHA() string {
-	if shaParsed == "" {
-		return ""
-	}
-
-	return shaParsed[:7]
+	return short(global.sha)
 }
 
 // src is a URL for the application source code. Typically links to a
@@ -288,13 +305,22 @@ func ShortSHA() string {
 //
 // Examples:
 //   -ldflags "-X 'jdk.sh/meta.src=https://example.com/demo.git'"
+//   -ldflags "-X 'jdk.sh/meta.src=git@example.com:demo.git'"
 var src string
 
-var srcParsed = mustURL("jdk.sh/meta.src", src)
-
-// Source is the URL for the application source code.
+// Source is the URL for the application source code. If not set, a URL derived
+// from the main module path is used instead. A git remote, such as
+// git@example.com:demo.git or ssh://git@example.com/demo.git, is converted into
+// a browsable https:// URL, such as https://example.com/demo.
 func Source() *u.URL {
-	return srcParsed
+	return global.src
+}
+
+// CloneURL is the URL for cloning the application source code, exactly as it
+// was set. Unlike Source, it may be a git remote that is not an http:// or
+// https:// URL.
+func CloneURL() string {
+	return global.raw["jdk.sh/meta.src"]
 }
 
 // title is the title of the application. Typically a full or non-abbreviated
@@ -309,7 +335,7 @@ var title string
 
 // Title is the title of the application.
 func Title() string {
-	return title
+	return global.title
 }
 
 // url is a URL for the application homepage. Typically links to a page where a
@@ -322,11 +348,10 @@ func Title() string {
 //   -ldflags "-X 'jdk.sh/meta.url=https://example.com/demo'"
 var url string
 
-var urlParsed = mustURL("jdk.sh/meta.url", url)
-
-// URL is the homepage URL for the application.
+// URL is the homepage URL for the application. If not set, a URL derived from
+// the main module path is used instead.
 func URL() *u.URL {
-	return urlParsed
+	return global.url
 }
 
 // version is the version slug for the application. The value can be used to
@@ -342,39 +367,38 @@ func URL() *u.URL {
 //   -ldflags "-X 'jdk.sh/meta.version=$(git describe)'"
 var version string
 
-// Version is the version slug for the application.
+// Version is the version slug for the application. If not set, the version of
+// the main module is used, when built from a versioned module.
 func Version() string {
-	return version
+	return global.version
 }
 
-var versionMajor, versionMinor, versionPatch, versionPreRelease, versionBuild = mustSemver("jdk.sh/version", version)
-
 // VersionMajor is the semver major version.
 // See https://semver.org.
 func VersionMajor() string {
-	return versionMajor
+	return global.semver.Major
 }
 
 // VersionMinor is the semver minor version.
 // See https://semver.org.
 func VersionMinor() string {
-	return versionMinor
+	return global.semver.Minor
 }
 
 // VersionPatch is the semver patch version.
 // See https://semver.org.
 func VersionPatch() string {
-	return versionPatch
+	return global.semver.Patch
 }
 
 // VersionPreRelease is the semver pre-release version.
 // See https://semver.org.
 func VersionPreRelease() string {
-	return versionPreRelease
+	return global.semver.PreRelease
 }
 
 // VersionBuild is the semver build metadata version.
 // See https://semver.org.
 func VersionBuild() string {
-	return versionBuild
+	return global.semver.Build
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	u "net/url"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -177,6 +178,33 @@ func TestMeta(t *testing.T) { // nolint:funlen
 				equalString(t, runtime.GOOS, actual.OS)
 			},
 		},
+		{
+			// Values used when rendering a template.
+			flags: map[string]string{
+				"jdk.sh/meta.date":    "Fri, 23 Aug 2019 11:00:00 -0700",
+				"jdk.sh/meta.name":    "demo-app",
+				"jdk.sh/meta.sha":     "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+				"jdk.sh/meta.version": "v1.2.3",
+			},
+			assertfn: func(t *testing.T, actual *info) {
+				equalString(t, "demo-app bb2fecb 1 2019", actual.Render)
+			},
+		},
+		{
+			// Placeholder values are treated as if they were not set.
+			flags: map[string]string{
+				"jdk.sh/meta.date":    "META_RESERVED____________",
+				"jdk.sh/meta.name":    "META_RESERVED____________",
+				"jdk.sh/meta.url":     "META_RESERVED____________",
+				"jdk.sh/meta.version": "META_RESERVED____________",
+			},
+			assertfn: func(t *testing.T, actual *info) {
+				equalTime(t, nil, actual.Date)
+				equalString(t, "", actual.Name)
+				equalURL(t, nil, actual.URL)
+				equalString(t, "", actual.Version)
+			},
+		},
 		{
 			// Value for jdk.sh/meta.sha that is valid.
 			flags: map[string]string{
@@ -235,6 +263,19 @@ func TestMeta(t *testing.T) { // nolint:funlen
 			},
 			panics: true,
 		},
+		{
+			// Values used when building a User-Agent.
+			flags: map[string]string{
+				"jdk.sh/meta.name":    "Demo App",
+				"jdk.sh/meta.sha":     "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+				"jdk.sh/meta.version": "v1.2.3",
+			},
+			assertfn: func(t *testing.T, actual *info) {
+				expected := fmt.Sprintf("Demo-App/v1.2.3 (%s; %s; bb2fecb) go/%s",
+					runtime.GOOS, runtime.GOARCH, strings.TrimPrefix(runtime.Version(), "go"))
+				equalString(t, expected, actual.UserAgent)
+			},
+		},
 		{
 			// Value for jdk.sh/meta.version.
 			flags: map[string]string{
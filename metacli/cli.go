@@ -0,0 +1,44 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+// Package metacli provides a ready-made version subcommand and version printer
+// for applications built with github.com/urfave/cli, populated from the values
+// in jdk.sh/meta.
+package metacli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"jdk.sh/meta/format"
+)
+
+// Command returns a "version" subcommand that prints the application metadata.
+// The output format can be selected with the --output flag.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "version",
+		Usage: "Print the application version information",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   fmt.Sprintf("output format, one of %s", strings.Join(format.Formats, "|")),
+				Value:   format.Text,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return format.Write(ctx.App.Writer, ctx.String("output"))
+		},
+	}
+}
+
+// VersionPrinter prints the application metadata as text when the --version
+// flag is given. It can be used by assigning it to cli.VersionPrinter.
+func VersionPrinter(ctx *cli.Context) {
+	// Text output can only fail if the underlying writer fails, and there is
+	// nowhere left to report that.
+	_ = format.Write(ctx.App.Writer, format.Text)
+}
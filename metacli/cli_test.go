@@ -0,0 +1,81 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package metacli
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args     []string
+		expected string
+		fails    bool
+	}{
+		{
+			args:     []string{"demo-app", "version"},
+			expected: "go:   " + runtime.Version() + "\n",
+		},
+		{
+			args:     []string{"demo-app", "version", "--output", "json"},
+			expected: `"go": "` + runtime.Version() + `"`,
+		},
+		{
+			args:     []string{"demo-app", "version", "-o", "yaml"},
+			expected: `go: "` + runtime.Version() + `"`,
+		},
+		{
+			args:  []string{"demo-app", "version", "--output", "xml"},
+			fails: true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			app := &cli.App{
+				Commands: []*cli.Command{Command()},
+				Writer:   &buf,
+			}
+
+			err := app.Run(test.args)
+
+			switch {
+			case err != nil && !test.fails:
+				t.Fatalf("expected success but got %v", err)
+			case err == nil && test.fails:
+				t.Fatal("expected failure but got success")
+			case !strings.Contains(buf.String(), test.expected):
+				t.Fatalf("expected output to contain %q but got %q", test.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestVersionPrinter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	app := &cli.App{Writer: &buf}
+	VersionPrinter(cli.NewContext(app, nil, nil))
+
+	if expected := "go:   " + runtime.Version() + "\n"; !strings.Contains(buf.String(), expected) {
+		t.Fatalf("expected output to contain %q but got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,18 @@
+module jdk.sh/meta/metacli
+
+go 1.18
+
+// Tests in this repository are run against the local copy of jdk.sh/meta.
+// Users of this module are not affected, and get the version required below.
+replace jdk.sh/meta => ../
+
+require (
+	github.com/urfave/cli/v2 v2.27.7
+	jdk.sh/meta v0.2.0
+)
+
+require (
+	github.com/cpuguy83/go-md2man/v2 v2.0.7 // indirect
+	github.com/russross/blackfriday/v2 v2.1.0 // indirect
+	github.com/xrash/smetrics v0.0.0-20240521201337-686a1a2994c1 // indirect
+)
@@ -0,0 +1,50 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+// Package metacobra provides a ready-made version subcommand and version
+// template for applications built with github.com/spf13/cobra, populated from
+// the values in jdk.sh/meta.
+package metacobra
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jdk.sh/meta/format"
+)
+
+// Command returns a "version" subcommand that prints the application metadata.
+// The output format can be selected with the --output flag.
+func Command() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the application version information",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return format.Write(cmd.OutOrStdout(), output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", format.Text,
+		fmt.Sprintf("output format, one of %s", strings.Join(format.Formats, "|")))
+
+	return cmd
+}
+
+// Template returns a version template, for use with
+// cobra.Command.SetVersionTemplate, that prints the application metadata as
+// text when the --version flag is given.
+func Template() string {
+	var buf bytes.Buffer
+
+	// Writing to a bytes.Buffer never fails.
+	_ = format.Write(&buf, format.Text)
+
+	// Escape any template delimiters that might be present in the values.
+	return strings.ReplaceAll(buf.String(), "{{", `{{"{{"}}`)
+}
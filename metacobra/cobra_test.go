@@ -0,0 +1,87 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package metacobra
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args     []string
+		expected string
+		fails    bool
+	}{
+		{
+			args:     []string{"version"},
+			expected: "go:   " + runtime.Version() + "\n",
+		},
+		{
+			args:     []string{"version", "--output", "json"},
+			expected: `"go": "` + runtime.Version() + `"`,
+		},
+		{
+			args:     []string{"version", "-o", "yaml"},
+			expected: `go: "` + runtime.Version() + `"`,
+		},
+		{
+			args:  []string{"version", "--output", "xml"},
+			fails: true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			root := &cobra.Command{Use: "demo-app", SilenceErrors: true, SilenceUsage: true}
+			root.AddCommand(Command())
+			root.SetOut(&buf)
+			root.SetArgs(test.args)
+
+			err := root.Execute()
+
+			switch {
+			case err != nil && !test.fails:
+				t.Fatalf("expected success but got %v", err)
+			case err == nil && test.fails:
+				t.Fatal("expected failure but got success")
+			case !strings.Contains(buf.String(), test.expected):
+				t.Fatalf("expected output to contain %q but got %q", test.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	root := &cobra.Command{Use: "demo-app", Version: "unused", Run: func(*cobra.Command, []string) {}}
+	root.SetVersionTemplate(Template())
+	root.SetOut(&buf)
+	root.SetArgs([]string{"--version"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "go:   " + runtime.Version() + "\n"; !strings.Contains(buf.String(), expected) {
+		t.Fatalf("expected output to contain %q but got %q", expected, buf.String())
+	}
+}
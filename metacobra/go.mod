@@ -0,0 +1,17 @@
+module jdk.sh/meta/metacobra
+
+go 1.18
+
+// Tests in this repository are run against the local copy of jdk.sh/meta.
+// Users of this module are not affected, and get the version required below.
+replace jdk.sh/meta => ../
+
+require (
+	github.com/spf13/cobra v1.10.2
+	jdk.sh/meta v0.2.0
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
+)
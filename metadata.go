@@ -0,0 +1,150 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"net/mail"
+	u "net/url"
+	"time"
+)
+
+// metadata is a complete set of validated and converted metadata values.
+type metadata struct {
+	author      string
+	authorEmail string
+	authorURL   *u.URL
+	copyright   string
+	date        *time.Time
+	desc        string
+	dev         bool
+	docs        *u.URL
+	license     string
+	licenseURL  *u.URL
+	name        string
+	note        string
+	sha         string
+	src         *u.URL
+	title       string
+	url         *u.URL
+	version     string
+	semver      Semver
+
+	// raw is the raw value of every variable, keyed by variable name.
+	raw map[string]string
+
+	// sources is where the value of every variable came from, keyed by
+	// variable name.
+	sources map[string]string
+
+	// values is the converted value of every variable that was set, with any
+	// placeholders expanded, keyed by variable name.
+	values map[string]interface{}
+}
+
+// global is the metadata for the application, as set using ldflags and every
+// other supported source.
+var global *metadata
+
+// newMetadata validates and converts the given raw values, keyed by variable
+// name. Placeholder values are treated as if they were not set. Any malformed
+// value causes a panic.
+func newMetadata(raw, sources map[string]string) *metadata { // nolint:forcetypeassert
+	m := &metadata{
+		raw:     make(map[string]string, len(fields)),
+		sources: make(map[string]string, len(fields)),
+		values:  make(map[string]interface{}, len(fields)),
+	}
+
+	values := make(map[string]interface{}, len(fields))
+
+	for _, f := range fields {
+		value := unreserved(raw[f.path])
+		m.raw[f.path] = value
+
+		if f.expand {
+			values[f.path] = f.parse(f.path, expandVersioned(value, raw))
+		} else {
+			values[f.path] = f.parse(f.path, value)
+		}
+
+		if value != "" {
+			m.values[f.path] = values[f.path]
+		}
+
+		switch source := sources[f.path]; {
+		case value == "":
+			m.sources[f.path] = SourceDefault
+		case source == "":
+			m.sources[f.path] = SourceLdflags
+		default:
+			m.sources[f.path] = source
+		}
+	}
+
+	author := values["jdk.sh/meta.author"].(mail.Address)
+	m.author, m.authorEmail = author.Name, author.Address
+	m.authorURL = values["jdk.sh/meta.author_url"].(*u.URL)
+	m.copyright = values["jdk.sh/meta.copyright"].(string)
+	m.date = values["jdk.sh/meta.date"].(*time.Time)
+	m.desc = values["jdk.sh/meta.desc"].(string)
+	m.dev = values["jdk.sh/meta.dev"].(bool)
+	m.docs = values["jdk.sh/meta.docs"].(*u.URL)
+	m.license = values["jdk.sh/meta.license"].(string)
+	m.licenseURL = values["jdk.sh/meta.license_url"].(*u.URL)
+	m.name = values["jdk.sh/meta.name"].(string)
+	m.note = values["jdk.sh/meta.note"].(string)
+	m.sha = values["jdk.sh/meta.sha"].(string)
+	m.src = values["jdk.sh/meta.src"].(*u.URL)
+	m.title = values["jdk.sh/meta.title"].(string)
+	m.url = values["jdk.sh/meta.url"].(*u.URL)
+	m.version = m.raw["jdk.sh/meta.version"]
+	m.semver = values["jdk.sh/meta.version"].(Semver)
+
+	return m
+}
+
+// snapshot returns a snapshot of the metadata. The Arch, Go, and OS fields are
+// left empty, since they describe the running application.
+func (m *metadata) snapshot() Snapshot {
+	snapshot := Snapshot{
+		Author:      m.author,
+		AuthorEmail: m.authorEmail,
+		AuthorURL:   urlString(m.authorURL),
+		Copyright:   m.copyright,
+		Description: m.desc,
+		Development: m.dev,
+		Docs:        urlString(m.docs),
+		License:     m.license,
+		LicenseURL:  urlString(m.licenseURL),
+		Name:        m.name,
+		Note:        m.note,
+		SHA:         m.sha,
+		ShortSHA:    short(m.sha),
+		Source:      urlString(m.src),
+		Title:       m.title,
+		URL:         urlString(m.url),
+		Version:     m.version,
+		Semver:      m.semver,
+	}
+
+	if m.date != nil {
+		snapshot.Date = m.date.Format(time.RFC3339)
+	}
+
+	// Variables that were not set are omitted.
+	for name, source := range m.sources {
+		if source == SourceDefault {
+			continue
+		}
+
+		if snapshot.Sources == nil {
+			snapshot.Sources = make(map[string]string)
+		}
+
+		snapshot.Sources[name] = source
+	}
+
+	return snapshot
+}
@@ -0,0 +1,152 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+// Package metatest provides helpers for replacing the application metadata
+// provided by jdk.sh/meta during tests, without rebuilding the test binary
+// with ldflags. Replaced values are provided by a meta.Provider, which is
+// given to the code under test, so every test sees only its own values.
+//
+// Replaced values are only seen through the returned meta.Provider. Functions
+// in jdk.sh/meta that read the application metadata directly, such as
+// meta.UserAgent or meta.Transport, always describe the test binary itself.
+//
+// Example:
+//   func TestBanner(t *testing.T) {
+//       t.Parallel()
+//       provider := metatest.Set(t, metatest.Name("demo-app"), metatest.Version("v1.2.3"))
+//
+//       if banner(provider) != "demo-app v1.2.3" {
+//           t.Fatal("unexpected banner")
+//       }
+//   }
+package metatest
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"jdk.sh/meta"
+)
+
+// Option sets the raw value of one or more variables.
+type Option func(values map[string]string)
+
+// Set returns a Provider for the application metadata, with the given values
+// taking precedence. Values that are not given are the same as those of the
+// application. Values are validated in the same manner as when the application
+// starts, and the test fails if any are malformed.
+//
+// The public functions in jdk.sh/meta are not affected, so there is nothing to
+// restore once the test finishes. Tests that call Set can use t.Parallel, and
+// can call Set again from subtests, without seeing the values of any other
+// test.
+func Set(t testing.TB, options ...Option) meta.Provider {
+	t.Helper()
+
+	values := make(map[string]string)
+	for _, field := range meta.Fields() {
+		values[field.Path] = field.Raw
+	}
+
+	for _, option := range options {
+		option(values)
+	}
+
+	provider, err := meta.StaticProvider(values)
+	if err != nil {
+		t.Fatal(err)
+
+		return nil
+	}
+
+	return provider
+}
+
+// Value sets the raw value of the named variable.
+func Value(name, value string) Option {
+	return func(values map[string]string) {
+		values[name] = value
+	}
+}
+
+// Author sets the value of jdk.sh/meta.author.
+func Author(author string) Option {
+	return Value("jdk.sh/meta.author", author)
+}
+
+// AuthorURL sets the value of jdk.sh/meta.author_url.
+func AuthorURL(url string) Option {
+	return Value("jdk.sh/meta.author_url", url)
+}
+
+// Copyright sets the value of jdk.sh/meta.copyright.
+func Copyright(copyright string) Option {
+	return Value("jdk.sh/meta.copyright", copyright)
+}
+
+// Date sets the value of jdk.sh/meta.date.
+func Date(date time.Time) Option {
+	return Value("jdk.sh/meta.date", date.Format(time.RFC3339))
+}
+
+// Description sets the value of jdk.sh/meta.desc.
+func Description(desc string) Option {
+	return Value("jdk.sh/meta.desc", desc)
+}
+
+// Development sets the value of jdk.sh/meta.dev.
+func Development(dev bool) Option {
+	return Value("jdk.sh/meta.dev", strconv.FormatBool(dev))
+}
+
+// Docs sets the value of jdk.sh/meta.docs.
+func Docs(url string) Option {
+	return Value("jdk.sh/meta.docs", url)
+}
+
+// License sets the value of jdk.sh/meta.license.
+func License(license string) Option {
+	return Value("jdk.sh/meta.license", license)
+}
+
+// LicenseURL sets the value of jdk.sh/meta.license_url.
+func LicenseURL(url string) Option {
+	return Value("jdk.sh/meta.license_url", url)
+}
+
+// Name sets the value of jdk.sh/meta.name.
+func Name(name string) Option {
+	return Value("jdk.sh/meta.name", name)
+}
+
+// Note sets the value of jdk.sh/meta.note.
+func Note(note string) Option {
+	return Value("jdk.sh/meta.note", note)
+}
+
+// SHA sets the value of jdk.sh/meta.sha.
+func SHA(sha string) Option {
+	return Value("jdk.sh/meta.sha", sha)
+}
+
+// Source sets the value of jdk.sh/meta.src.
+func Source(url string) Option {
+	return Value("jdk.sh/meta.src", url)
+}
+
+// Title sets the value of jdk.sh/meta.title.
+func Title(title string) Option {
+	return Value("jdk.sh/meta.title", title)
+}
+
+// URL sets the value of jdk.sh/meta.url.
+func URL(url string) Option {
+	return Value("jdk.sh/meta.url", url)
+}
+
+// Version sets the value of jdk.sh/meta.version.
+func Version(version string) Option {
+	return Value("jdk.sh/meta.version", version)
+}
@@ -0,0 +1,151 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package metatest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"jdk.sh/meta"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	// Each subtest replaces the metadata with a different version, and must
+	// only ever observe its own.
+	for i := 0; i < 10; i++ {
+		i, version := i, fmt.Sprintf("v1.%d.0", i)
+
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			provider := Set(t,
+				Name("demo-app"),
+				Version(version),
+				Date(time.Date(2019, 8, 23, 18, 0, 0, 0, time.UTC)),
+				Development(true),
+			)
+
+			if provider.Version() != version {
+				t.Fatalf("expected version %q but got %q", version, provider.Version())
+			}
+
+			if expected := fmt.Sprint(i); provider.Semver().Minor != expected {
+				t.Fatalf("expected minor version %q but got %q", expected, provider.Semver().Minor)
+			}
+
+			if provider.Name() != "demo-app" || !provider.Development() {
+				t.Fatalf("unexpected name %q or development %t", provider.Name(), provider.Development())
+			}
+
+			if provider.Date().Format(time.RFC3339) != "2019-08-23T18:00:00Z" {
+				t.Fatalf("unexpected date %v", provider.Date())
+			}
+		})
+	}
+}
+
+// TestSetIsolated verifies that values given to Set are not seen by anything
+// other than the returned Provider, and so can not leak into other tests.
+func TestSetIsolated(t *testing.T) {
+	t.Parallel()
+
+	provider := Set(t, Note("temporary"), Version("v9.9.9"))
+
+	if provider.Note() != "temporary" || provider.Version() != "v9.9.9" {
+		t.Fatalf("unexpected note %q or version %q", provider.Note(), provider.Version())
+	}
+
+	// The test binary is built without any values, and they must remain
+	// unset, while the provider above is still in use.
+	for _, other := range []meta.Provider{meta.DefaultProvider(), Set(t)} {
+		if other.Note() != "" || other.Version() != "" {
+			t.Fatalf("expected no note or version but got %q and %q", other.Note(), other.Version())
+		}
+	}
+
+	if meta.Note() != "" || meta.Version() != "" || meta.Data().Version != "" {
+		t.Fatalf("expected no note or version but got %q and %q", meta.Note(), meta.Version())
+	}
+}
+
+// TestSetNested verifies that Set can be called by both a test and its
+// subtests, each seeing only their own values.
+func TestSetNested(t *testing.T) {
+	t.Parallel()
+
+	parent := Set(t, Name("parent"), Version("v1.0.0"))
+
+	t.Run("sub", func(t *testing.T) {
+		child := Set(t, Name("child"))
+
+		if child.Name() != "child" || child.Version() != "" {
+			t.Fatalf("unexpected name %q or version %q", child.Name(), child.Version())
+		}
+	})
+
+	if parent.Name() != "parent" || parent.Version() != "v1.0.0" {
+		t.Fatalf("unexpected name %q or version %q", parent.Name(), parent.Version())
+	}
+}
+
+func TestSetSource(t *testing.T) {
+	t.Parallel()
+
+	provider := Set(t, Source("git@github.com:joshdk/meta.git"))
+
+	if source := provider.Source().String(); source != "https://github.com/joshdk/meta" {
+		t.Fatalf("unexpected source url %q", source)
+	}
+}
+
+func TestSetVersionedDocs(t *testing.T) {
+	t.Parallel()
+
+	provider := Set(t,
+		Docs("https://example.com/docs/{major}.{minor}/"),
+		LicenseURL("https://example.com/blob/{version}/LICENSE.txt"),
+		Version("v1.2.3"),
+	)
+
+	if docs := provider.Docs().String(); docs != "https://example.com/docs/1.2/" {
+		t.Fatalf("unexpected docs url %q", docs)
+	}
+
+	if license := provider.LicenseURL().String(); license != "https://example.com/blob/v1.2.3/LICENSE.txt" {
+		t.Fatalf("unexpected license url %q", license)
+	}
+}
+
+func TestSetMalformed(t *testing.T) {
+	t.Parallel()
+
+	for _, option := range []Option{
+		URL("example.com/page"),
+		SHA("abc"),
+		Value("jdk.sh/meta.lisense", "MIT"),
+	} {
+		var mock mockTB
+
+		if provider := Set(&mock, option); provider != nil || !mock.failed {
+			t.Fatal("expected test to fail")
+		}
+	}
+}
+
+// mockTB is a testing.TB that records failures, rather than stopping the
+// test.
+type mockTB struct {
+	testing.TB
+	failed bool
+}
+
+func (m *mockTB) Helper() {}
+
+func (m *mockTB) Fatal(...interface{}) {
+	m.failed = true
+}
@@ -0,0 +1,130 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// VersionPolicy describes which client versions are accepted by a server.
+type VersionPolicy struct {
+	// Header is the name of the request header containing the client version.
+	// Defaults to X-Client-Version, as set by Transport.
+	Header string
+
+	// Minimum is the lowest semver version that a client may have. Clients
+	// with a version that is not a properly formatted semver version are also
+	// rejected. Clients are not checked against a minimum version if empty.
+	Minimum string
+
+	// Deny is a list of specific client versions that are rejected.
+	Deny []string
+
+	// AllowMissing passes through requests that do not contain the client
+	// version header at all, so that the middleware can be used alongside
+	// other clients such as browsers. Such requests are rejected otherwise,
+	// since they may come from clients that are too old to send the header.
+	AllowMissing bool
+
+	// Upgrade is the value of the Upgrade header sent to rejected clients,
+	// which RFC 9110 requires for a 426 Upgrade Required response. Defaults to
+	// HTTP/1.1, since it is the client that must be upgraded rather than the
+	// protocol, but may be set to a product token naming the required client,
+	// such as demo-app/1.2.0.
+	Upgrade string
+}
+
+// upgradeResponse is the JSON body sent to a rejected client.
+type upgradeResponse struct {
+	Error          string `json:"error"`
+	ClientVersion  string `json:"client_version"`
+	MinimumVersion string `json:"minimum_version,omitempty"`
+	ServerVersion  string `json:"server_version,omitempty"`
+}
+
+// RequireVersion returns an http.Handler middleware that rejects requests from
+// outdated clients, with a 426 Upgrade Required response and a JSON body.
+// Requests that do not contain a client version header at all are also
+// rejected, unless the policy allows them.
+//
+// RequireVersion panics if the policy minimum is not a properly formatted
+// semver version.
+func RequireVersion(policy VersionPolicy, next http.Handler) http.Handler {
+	if policy.Header == "" {
+		policy.Header = HeaderClientVersion
+	}
+
+	if policy.Upgrade == "" {
+		policy.Upgrade = "HTTP/1.1"
+	}
+
+	if policy.Minimum != "" {
+		if _, ok := compareSemver(policy.Minimum, policy.Minimum); !ok {
+			panic(fmt.Errorf("malformed minimum version %s", policy.Minimum))
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientVersion := r.Header.Get(policy.Header)
+		if clientVersion == "" && policy.AllowMissing {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if reason := policy.check(clientVersion); reason != "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Upgrade", policy.Upgrade)
+			w.WriteHeader(http.StatusUpgradeRequired)
+
+			// There is nothing useful to do if the response can't be written.
+			_ = json.NewEncoder(w).Encode(upgradeResponse{
+				Error:          reason,
+				ClientVersion:  clientVersion,
+				MinimumVersion: policy.Minimum,
+				ServerVersion:  Version(),
+			})
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// check returns the reason that the given client version is rejected, or an
+// empty string if it is accepted.
+func (p VersionPolicy) check(clientVersion string) string {
+	if clientVersion == "" {
+		return fmt.Sprintf("client version header %s is missing", p.Header)
+	}
+
+	for _, denied := range p.Deny {
+		if clientVersion == denied {
+			return fmt.Sprintf("client version %s is not supported", clientVersion)
+		}
+
+		if result, ok := compareSemver(clientVersion, denied); ok && result == 0 {
+			return fmt.Sprintf("client version %s is not supported", clientVersion)
+		}
+	}
+
+	if p.Minimum == "" {
+		return ""
+	}
+
+	result, ok := compareSemver(clientVersion, p.Minimum)
+
+	switch {
+	case !ok:
+		return fmt.Sprintf("client version %s is malformed", clientVersion)
+	case result < 0:
+		return fmt.Sprintf("client version %s is older than the minimum version %s", clientVersion, p.Minimum)
+	default:
+		return ""
+	}
+}
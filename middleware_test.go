@@ -0,0 +1,157 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireVersion(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		policy   VersionPolicy
+		header   string
+		version  string
+		rejected bool
+	}{
+		{
+			// No policy at all.
+			version: "v1.0.0",
+		},
+		{
+			// No client version header.
+			policy:   VersionPolicy{Minimum: "v1.2.0"},
+			rejected: true,
+		},
+		{
+			// No client version header, but missing headers are allowed.
+			policy: VersionPolicy{Minimum: "v1.2.0", AllowMissing: true},
+		},
+		{
+			// No client version header, and no policy at all.
+			rejected: true,
+		},
+		{
+			policy:  VersionPolicy{Minimum: "v1.2.0"},
+			version: "v1.2.0",
+		},
+		{
+			policy:  VersionPolicy{Minimum: "v1.2.0"},
+			version: "v1.10.0",
+		},
+		{
+			policy:   VersionPolicy{Minimum: "v1.2.0"},
+			version:  "v1.1.9",
+			rejected: true,
+		},
+		{
+			policy:   VersionPolicy{Minimum: "v1.2.0"},
+			version:  "v1.2.0-rc.1",
+			rejected: true,
+		},
+		{
+			policy:   VersionPolicy{Minimum: "v1.2.0"},
+			version:  "development",
+			rejected: true,
+		},
+		{
+			policy:   VersionPolicy{Deny: []string{"v1.3.1", "development"}},
+			version:  "1.3.1",
+			rejected: true,
+		},
+		{
+			policy:   VersionPolicy{Deny: []string{"v1.3.1", "development"}},
+			version:  "development",
+			rejected: true,
+		},
+		{
+			policy:  VersionPolicy{Deny: []string{"v1.3.1"}},
+			version: "v1.3.2",
+		},
+		{
+			// Custom header name.
+			policy:   VersionPolicy{Header: "X-App-Version", Minimum: "v1.2.0"},
+			header:   "X-App-Version",
+			version:  "v1.0.0",
+			rejected: true,
+		},
+		{
+			// Custom header name, but the default header was given, and
+			// missing headers are allowed.
+			policy:  VersionPolicy{Header: "X-App-Version", Minimum: "v1.2.0", AllowMissing: true},
+			version: "v1.0.0",
+		},
+		{
+			// Custom upgrade header.
+			policy:   VersionPolicy{Minimum: "v1.2.0", Upgrade: "demo-app/1.2.0"},
+			version:  "v1.0.0",
+			rejected: true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			handler := RequireVersion(test.policy, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.version != "" {
+				header := test.header
+				if header == "" {
+					header = HeaderClientVersion
+				}
+
+				req.Header.Set(header, test.version)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			if !test.rejected {
+				if recorder.Code != http.StatusNoContent {
+					t.Fatalf("expected status %d but got %d", http.StatusNoContent, recorder.Code)
+				}
+
+				return
+			}
+
+			if recorder.Code != http.StatusUpgradeRequired {
+				t.Fatalf("expected status %d but got %d", http.StatusUpgradeRequired, recorder.Code)
+			}
+
+			upgrade := test.policy.Upgrade
+			if upgrade == "" {
+				upgrade = "HTTP/1.1"
+			}
+
+			equalString(t, upgrade, recorder.Header().Get("Upgrade"))
+
+			var body upgradeResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			equalString(t, test.version, body.ClientVersion)
+			equalString(t, test.policy.Minimum, body.MinimumVersion)
+		})
+	}
+}
+
+func TestRequireVersionMalformed(t *testing.T) {
+	t.Parallel()
+
+	defer equalPanic(t, true)
+	RequireVersion(VersionPolicy{Minimum: "latest"}, http.NotFoundHandler())
+}
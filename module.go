@@ -0,0 +1,102 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Module returns the module with the given path, as recorded in the build
+// information embedded by the Go toolchain, including its version, checksum,
+// and any replacement. This allows a library to report its own version when
+// embedded in another application. The main module is also returned, if
+// given its path. Returns nil if the module is not found, or if the
+// application was built without module support.
+func Module(path string) *debug.Module {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	return findModule(info, path)
+}
+
+// Self returns the module that contains the package of the calling function,
+// in the same manner as Module. Returns nil if the module is not found.
+func Self() *debug.Module {
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return nil
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return nil
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	return containingModule(info, packagePath(fn.Name()))
+}
+
+// findModule returns the module with the given path, or nil if not found.
+func findModule(info *debug.BuildInfo, path string) *debug.Module {
+	if info.Main.Path == path {
+		return &info.Main
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == path {
+			return dep
+		}
+	}
+
+	return nil
+}
+
+// containingModule returns the module that contains the package with the
+// given import path, or nil if not found. Since modules can be nested, the
+// module with the longest matching path is chosen.
+func containingModule(info *debug.BuildInfo, pkg string) *debug.Module {
+	// Functions in the main package are named main.<name>, rather than using
+	// the import path of the package.
+	if pkg == "main" {
+		return &info.Main
+	}
+
+	var result *debug.Module
+
+	for _, module := range append([]*debug.Module{&info.Main}, info.Deps...) {
+		if module.Path == "" || (pkg != module.Path && !strings.HasPrefix(pkg, module.Path+"/")) {
+			continue
+		}
+
+		if result == nil || len(module.Path) > len(result.Path) {
+			result = module
+		}
+	}
+
+	return result
+}
+
+// packagePath returns the import path of the package that contains the named
+// function, as returned by runtime.Func.Name. For example,
+// example.com/demo/pkg.(*Type).Method is in the package example.com/demo/pkg.
+func packagePath(function string) string {
+	// The first dot after the last slash separates the package path from the
+	// function name, since the toolchain escapes any dots in the last element
+	// of the package path.
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		function = function[:slash+1+dot]
+	}
+
+	return strings.ReplaceAll(function, "%2e", ".")
+}
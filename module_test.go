@@ -0,0 +1,87 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestSelf(t *testing.T) {
+	t.Parallel()
+
+	module := Self()
+	if module == nil {
+		t.Fatal("expected a module but got nil")
+	}
+
+	equalString(t, "jdk.sh/meta", module.Path)
+
+	if module := Module("jdk.sh/meta"); module == nil || module.Path != "jdk.sh/meta" {
+		t.Fatalf("expected the main module but got %v", module)
+	}
+
+	if module := Module("example.com/missing"); module != nil {
+		t.Fatalf("expected no module but got %v", module)
+	}
+}
+
+func TestContainingModule(t *testing.T) {
+	t.Parallel()
+
+	info := &debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/demo"},
+		Deps: []*debug.Module{
+			{Path: "example.com/lib", Version: "v1.2.3", Sum: "h1:abc="},
+			{Path: "example.com/lib/nested", Version: "v0.1.0"},
+			{Path: "example.com/fork", Version: "v1.0.0", Replace: &debug.Module{Path: "../fork"}},
+		},
+	}
+
+	tests := map[string]string{
+		"example.com/demo":            "example.com/demo",
+		"example.com/demo/cmd/demo":   "example.com/demo",
+		"example.com/lib":             "example.com/lib",
+		"example.com/lib/pkg":         "example.com/lib",
+		"example.com/lib/nested/pkg":  "example.com/lib/nested",
+		"example.com/library":         "",
+		"example.com/fork/pkg":        "example.com/fork",
+		"example.com/unrelated/thing": "",
+		"main":                        "example.com/demo",
+	}
+
+	for pkg, expected := range tests {
+		var actual string
+		if module := containingModule(info, pkg); module != nil {
+			actual = module.Path
+		}
+
+		if actual != expected {
+			t.Fatalf("expected module %q for package %s but got %q", expected, pkg, actual)
+		}
+	}
+
+	if module := findModule(info, "example.com/fork"); module == nil || module.Replace.Path != "../fork" {
+		t.Fatalf("expected a replaced module but got %v", module)
+	}
+}
+
+func TestPackagePath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"main.main":                           "main",
+		"jdk.sh/meta.Self":                    "jdk.sh/meta",
+		"example.com/demo/pkg.(*Type).Method": "example.com/demo/pkg",
+		"example.com/demo/pkg.Func.func1":     "example.com/demo/pkg",
+		// Dots in the last element of the package path are escaped.
+		"gopkg.in/yaml%2ev3.Unmarshal":             "gopkg.in/yaml.v3",
+		"example.com/demo/pkg%2ev2.(*Type).Method": "example.com/demo/pkg.v2",
+	}
+
+	for function, expected := range tests {
+		equalString(t, expected, packagePath(function))
+	}
+}
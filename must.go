@@ -137,3 +137,47 @@ func mustURL(path, raw string) *u.URL {
 
 	return parsed
 }
+
+// scpRegex matches an SCP-like git remote, such as git@github.com:org/repo.git,
+// capturing the user, host, and path.
+var scpRegex = regexp.MustCompile(`^(?:([\w.+-]+)@)?([\w.-]+):(.+)$`)
+
+// mustSourceURL validates that the given value is a properly formatted source
+// URL. In addition to http:// and https:// URLs, git remotes are accepted in
+// the SCP-like form, or with an ssh://, git+ssh://, or git:// scheme, and are
+// converted into a browsable https:// URL.
+func mustSourceURL(path, raw string) *u.URL {
+	if raw == "" || strings.HasPrefix(raw, "http://") || strings.HasPrefix(raw, "https://") {
+		return mustURL(path, raw)
+	}
+
+	var host, repo string
+
+	// An SCP-like remote must have a user or a dotted host, so that a URL
+	// with some other scheme, such as mailto:jdoe@example.com, is not mistaken
+	// for one.
+	if matches := scpRegex.FindStringSubmatch(raw); matches != nil && !strings.Contains(raw, "://") &&
+		(matches[1] != "" || strings.Contains(matches[2], ".")) {
+		host, repo = matches[2], matches[3]
+	} else {
+		parsed, err := u.Parse(raw)
+		if err != nil {
+			panic(fmt.Errorf("malformed ldflags value for %s", path))
+		}
+
+		// Require that the scheme is one used by git remotes.
+		if parsed.Scheme != "ssh" && parsed.Scheme != "git+ssh" && parsed.Scheme != "git" {
+			panic(fmt.Errorf("malformed ldflags value for %s", path))
+		}
+
+		// The port is dropped, since it is not the port of the web server.
+		host, repo = parsed.Hostname(), parsed.Path
+	}
+
+	repo = strings.TrimSuffix(strings.Trim(repo, "/"), ".git")
+	if host == "" || repo == "" {
+		panic(fmt.Errorf("malformed ldflags value for %s", path))
+	}
+
+	return mustURL(path, "https://"+host+"/"+repo)
+}
@@ -358,6 +358,85 @@ func TestMustURL(t *testing.T) {
 	}
 }
 
+func TestMustSourceURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected *u.URL
+		panic    bool
+	}{
+		{
+			input:    "",
+			expected: nil,
+		},
+		{
+			input:    "https://example.com/demo.git",
+			expected: &u.URL{Scheme: "https", Host: "example.com", Path: "/demo.git"},
+		},
+		{
+			input:    "git@github.com:org/repo.git",
+			expected: &u.URL{Scheme: "https", Host: "github.com", Path: "/org/repo"},
+		},
+		{
+			input:    "example.com:demo",
+			expected: &u.URL{Scheme: "https", Host: "example.com", Path: "/demo"},
+		},
+		{
+			input:    "ssh://git@example.com:2222/org/repo.git",
+			expected: &u.URL{Scheme: "https", Host: "example.com", Path: "/org/repo"},
+		},
+		{
+			input:    "git+ssh://git@example.com/org/repo",
+			expected: &u.URL{Scheme: "https", Host: "example.com", Path: "/org/repo"},
+		},
+		{
+			input:    "git://example.com/org/repo.git/",
+			expected: &u.URL{Scheme: "https", Host: "example.com", Path: "/org/repo"},
+		},
+		{
+			input: "git@github.com:",
+			panic: true,
+		},
+		{
+			input: "ssh://git@example.com",
+			panic: true,
+		},
+		{
+			input: "ftp://example.com/demo.git",
+			panic: true,
+		},
+		{
+			input: "example.com",
+			panic: true,
+		},
+		{
+			input: "mailto:jdoe@example.com",
+			panic: true,
+		},
+		{
+			input: "javascript:alert(1)",
+			panic: true,
+		},
+		{
+			input:    "git@localhost:demo",
+			expected: &u.URL{Scheme: "https", Host: "localhost", Path: "/demo"},
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			defer equalPanic(t, test.panic)
+			actual := mustSourceURL("", test.input)
+			equalURL(t, test.expected, actual)
+		})
+	}
+}
+
 func equalPanic(t *testing.T, panics bool) {
 	t.Helper()
 
@@ -0,0 +1,38 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"strings"
+)
+
+// reservedPrefix is the prefix of every placeholder value.
+const reservedPrefix = "META_RESERVED"
+
+// Reserve returns a placeholder value of the given size, which can be used
+// with -X to reserve space for a variable in a binary. The value can then be
+// stamped into the binary at a later time, without rebuilding it. Placeholder
+// values are treated as if the variable had not been set at all. The size is
+// rounded up to a minimum of 13 bytes.
+//
+// Examples:
+//   -ldflags "-X 'jdk.sh/meta.version=META_RESERVED___________________'"
+func Reserve(size int) string {
+	if size <= len(reservedPrefix) {
+		return reservedPrefix
+	}
+
+	return reservedPrefix + strings.Repeat("_", size-len(reservedPrefix))
+}
+
+// unreserved returns the given value, or an empty string if it is a
+// placeholder value.
+func unreserved(raw string) string {
+	if strings.HasPrefix(raw, reservedPrefix) && strings.Trim(raw[len(reservedPrefix):], "_") == "" {
+		return ""
+	}
+
+	return raw
+}
@@ -0,0 +1,53 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestReserve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		size     int
+		expected string
+	}{
+		{
+			size:     0,
+			expected: "META_RESERVED",
+		},
+		{
+			size:     13,
+			expected: "META_RESERVED",
+		},
+		{
+			size:     20,
+			expected: "META_RESERVED_______",
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			actual := Reserve(test.size)
+			equalString(t, test.expected, actual)
+			equalString(t, "", unreserved(actual))
+		})
+	}
+}
+
+func TestUnreserved(t *testing.T) {
+	t.Parallel()
+
+	equalString(t, "", unreserved(""))
+	equalString(t, "v1.2.3", unreserved("v1.2.3"))
+	equalString(t, "META_RESERVED_x", unreserved("META_RESERVED_x"))
+	equalString(t, "", unreserved("META_RESERVED____"))
+}
@@ -0,0 +1,155 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	u "net/url"
+	"runtime/debug"
+	"time"
+)
+
+// Provider provides application metadata. Libraries can accept a Provider as
+// a dependency, rather than calling the public functions in this package
+// directly, so that they can be given metadata other than that of the main
+// application.
+type Provider interface {
+	// Author is the name of the application author.
+	Author() string
+
+	// AuthorEmail is the email address for the application author.
+	AuthorEmail() string
+
+	// AuthorURL is the homepage URL for the application author.
+	AuthorURL() *u.URL
+
+	// Copyright is the copyright for the application.
+	Copyright() string
+
+	// Date is the time at which the application was built.
+	Date() *time.Time
+
+	// Description is the description of the application.
+	Description() string
+
+	// Development is the development status for the application.
+	Development() bool
+
+	// Docs is the documentation URL for the application.
+	Docs() *u.URL
+
+	// License is the license identifier for the application.
+	License() string
+
+	// LicenseURL is the license URL for the application.
+	LicenseURL() *u.URL
+
+	// Name is the name of the application.
+	Name() string
+
+	// Note is an arbitrary message for the application.
+	Note() string
+
+	// SHA is the git SHA used to build the application.
+	SHA() string
+
+	// Source is the URL for the application source code.
+	Source() *u.URL
+
+	// Title is the title of the application.
+	Title() string
+
+	// URL is the homepage URL for the application.
+	URL() *u.URL
+
+	// Version is the version slug for the application.
+	Version() string
+
+	// Semver is the semver components of the version.
+	Semver() Semver
+}
+
+// DefaultProvider returns the Provider that backs the public functions in
+// this package, which provides the metadata for the main application.
+func DefaultProvider() Provider {
+	return defaultProvider{}
+}
+
+// StaticProvider returns a Provider for the given raw values, keyed by
+// variable name. Values are validated in the same manner as when the
+// application starts, and an error is returned for any malformed value.
+func StaticProvider(values map[string]string) (provider Provider, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	for name := range values {
+		if lookupField(name) == nil {
+			return nil, fmt.Errorf("unknown variable %s", name)
+		}
+	}
+
+	return newMetadata(values, nil), nil
+}
+
+// BuildInfoProvider returns a Provider for the values that can be inferred
+// from the given build information, such as from debug.ReadBuildInfo. The
+// version is taken from the main module version, the SHA and date are taken
+// from the VCS revision and commit time, and the source and homepage URLs are
+// derived from the main module path. Every other value is empty.
+func BuildInfoProvider(info *debug.BuildInfo) Provider {
+	values := inferValues(info)
+
+	sources := make(map[string]string, len(values))
+	for name := range values {
+		sources[name] = SourceBuildInfo
+	}
+
+	return newMetadata(values, sources)
+}
+
+// defaultProvider is a Provider that returns the values from the public
+// functions in this package.
+type defaultProvider struct{}
+
+func (defaultProvider) Author() string      { return Author() }
+func (defaultProvider) AuthorEmail() string { return AuthorEmail() }
+func (defaultProvider) AuthorURL() *u.URL   { return AuthorURL() }
+func (defaultProvider) Copyright() string   { return Copyright() }
+func (defaultProvider) Date() *time.Time    { return Date() }
+func (defaultProvider) Description() string { return Description() }
+func (defaultProvider) Development() bool   { return Development() }
+func (defaultProvider) Docs() *u.URL        { return Docs() }
+func (defaultProvider) License() string     { return License() }
+func (defaultProvider) LicenseURL() *u.URL  { return LicenseURL() }
+func (defaultProvider) Name() string        { return Name() }
+func (defaultProvider) Note() string        { return Note() }
+func (defaultProvider) SHA() string         { return SHA() }
+func (defaultProvider) Source() *u.URL      { return Source() }
+func (defaultProvider) Title() string       { return Title() }
+func (defaultProvider) URL() *u.URL         { return URL() }
+func (defaultProvider) Version() string     { return Version() }
+func (defaultProvider) Semver() Semver      { return global.semver }
+
+func (m *metadata) Author() string      { return m.author }
+func (m *metadata) AuthorEmail() string { return m.authorEmail }
+func (m *metadata) AuthorURL() *u.URL   { return m.authorURL }
+func (m *metadata) Copyright() string   { return m.copyright }
+func (m *metadata) Date() *time.Time    { return m.date }
+func (m *metadata) Description() string { return m.desc }
+func (m *metadata) Development() bool   { return m.dev }
+func (m *metadata) Docs() *u.URL        { return m.docs }
+func (m *metadata) License() string     { return m.license }
+func (m *metadata) LicenseURL() *u.URL  { return m.licenseURL }
+func (m *metadata) Name() string        { return m.name }
+func (m *metadata) Note() string        { return m.note }
+func (m *metadata) SHA() string         { return m.sha }
+func (m *metadata) Source() *u.URL      { return m.src }
+func (m *metadata) Title() string       { return m.title }
+func (m *metadata) URL() *u.URL         { return m.url }
+func (m *metadata) Version() string     { return m.version }
+func (m *metadata) Semver() Semver      { return m.semver }
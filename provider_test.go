@@ -0,0 +1,98 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestStaticProvider(t *testing.T) {
+	t.Parallel()
+
+	provider, err := StaticProvider(map[string]string{
+		"jdk.sh/meta.author":  "Jane Doe <jdoe@example.com>",
+		"jdk.sh/meta.name":    "demo-app",
+		"jdk.sh/meta.url":     "https://example.com/page",
+		"jdk.sh/meta.version": "v1.2.3-rc.456",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equalString(t, "Jane Doe", provider.Author())
+	equalString(t, "jdoe@example.com", provider.AuthorEmail())
+	equalString(t, "demo-app", provider.Name())
+	equalString(t, "https://example.com/page", provider.URL().String())
+	equalString(t, "v1.2.3-rc.456", provider.Version())
+	equalString(t, "rc.456", provider.Semver().PreRelease)
+	equalString(t, "", provider.SHA())
+
+	if provider.Docs() != nil {
+		t.Fatalf("expected no docs but got %v", provider.Docs())
+	}
+}
+
+func TestStaticProviderMalformed(t *testing.T) {
+	t.Parallel()
+
+	for _, values := range []map[string]string{
+		{"jdk.sh/meta.url": "example.com/page"},
+		{"jdk.sh/meta.lisense": "MIT"},
+	} {
+		if _, err := StaticProvider(values); err == nil {
+			t.Fatalf("expected an error for %v but got none", values)
+		}
+	}
+}
+
+func TestBuildInfoProvider(t *testing.T) {
+	t.Parallel()
+
+	provider := BuildInfoProvider(&debug.BuildInfo{
+		Main: debug.Module{Path: "github.com/joshdk/meta/v2", Version: "v2.1.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6"},
+			{Key: "vcs.time", Value: "2019-08-23T18:00:00Z"},
+		},
+	})
+
+	equalString(t, "v2.1.0", provider.Version())
+	equalString(t, "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6", provider.SHA())
+	equalString(t, "2019-08-23 18:00:00 +0000 UTC", provider.Date().String())
+	equalString(t, "https://github.com/joshdk/meta", provider.Source().String())
+	equalString(t, "https://github.com/joshdk/meta", provider.URL().String())
+	equalString(t, "", provider.Name())
+
+	// A development build of a module with a vanity path has no version or
+	// source.
+	provider = BuildInfoProvider(&debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/demo", Version: develVersion},
+	})
+
+	equalString(t, "", provider.Version())
+
+	if provider.Source() != nil || provider.URL() != nil {
+		t.Fatalf("expected no source or url but got %v and %v", provider.Source(), provider.URL())
+	}
+}
+
+// TestDefaultProvider modifies global state, and so must not run in parallel.
+func TestDefaultProvider(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	// The provider reflects the current values, even those set after it was
+	// created.
+	provider := DefaultProvider()
+
+	Register(map[string]string{
+		"jdk.sh/meta.name":    "demo-app",
+		"jdk.sh/meta.version": "v1.2.3",
+	})
+
+	equalString(t, "demo-app", provider.Name())
+	equalString(t, "v1.2.3", provider.Version())
+	equalString(t, "2", provider.Semver().Minor)
+}
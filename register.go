@@ -0,0 +1,43 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+)
+
+// Variables returns the sorted list of every variable name that can be set
+// using ldflags.
+func Variables() []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.path)
+	}
+
+	return names
+}
+
+// Register sets the values of the given variables, keyed by variable name. It
+// is intended to be called from an init function in code generated by the
+// meta generate command, so that builds which cannot use ldflags still carry
+// metadata. Values that were set using ldflags always take precedence.
+//
+// Register panics if given an unknown variable name, or a malformed value, in
+// the same manner as a malformed ldflags value.
+func Register(values map[string]string) {
+	for name, value := range values {
+		f := lookupField(name)
+		if f == nil {
+			panic(fmt.Errorf("unknown variable %s", name))
+		}
+
+		if unreserved(*f.variable) == "" {
+			*f.variable = value
+			f.source = SourceGenerated
+		}
+	}
+
+	parse()
+}
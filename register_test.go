@@ -0,0 +1,83 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"testing"
+)
+
+// restoreVariables saves the raw value, source, and fallback value of every
+// variable, and restores them once the test finishes. Override values are
+// reloaded from the environment.
+func restoreVariables(t *testing.T) {
+	t.Helper()
+
+	saved := make([]field, len(fields))
+	for i, f := range fields {
+		saved[i] = *f
+		saved[i].variable = new(string)
+		*saved[i].variable = *f.variable
+	}
+
+	t.Cleanup(func() {
+		for i, f := range fields {
+			*f.variable = *saved[i].variable
+			f.source, f.fallback = saved[i].source, saved[i].fallback
+		}
+
+		parse()
+	})
+}
+
+// TestRegister modifies global state, and so must not run in parallel.
+func TestRegister(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	// Simulate a value that was set using ldflags.
+	version = "v1.0.0"
+
+	Register(map[string]string{
+		"jdk.sh/meta.name":    "demo-app",
+		"jdk.sh/meta.url":     "https://example.com/page",
+		"jdk.sh/meta.version": "v2.0.0",
+	})
+
+	equalString(t, "demo-app", Name())
+	equalString(t, "https://example.com/page", URL().String())
+	equalString(t, "v1.0.0", Version())
+	equalString(t, "1", VersionMajor())
+
+	for _, f := range Fields() {
+		switch f.Path {
+		case "jdk.sh/meta.name", "jdk.sh/meta.url":
+			equalString(t, SourceGenerated, f.Source)
+		case "jdk.sh/meta.version":
+			equalString(t, SourceLdflags, f.Source)
+		}
+	}
+
+	equalString(t, SourceGenerated, Data().Sources["jdk.sh/meta.name"])
+}
+
+// TestRegisterMalformed modifies global state, and so must not run in
+// parallel.
+func TestRegisterMalformed(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	defer equalPanic(t, true)
+	Register(map[string]string{
+		"jdk.sh/meta.url": "example.com/page",
+	})
+}
+
+// TestRegisterUnknown modifies global state, and so must not run in parallel.
+func TestRegisterUnknown(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	defer equalPanic(t, true)
+	Register(map[string]string{
+		"jdk.sh/meta.lisense": "MIT",
+	})
+}
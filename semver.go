@@ -0,0 +1,95 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareSemver compares the precedence of the two given semver versions,
+// returning -1, 0, or +1. Build metadata is ignored, as required by the
+// specification. The boolean result is false if either version is not a
+// properly formatted semver version.
+// See https://semver.org/#spec-item-11.
+func compareSemver(a, b string) (int, bool) {
+	aMajor, aMinor, aPatch, aPreRelease, _ := mustSemver("", a)
+	bMajor, bMinor, bPatch, bPreRelease, _ := mustSemver("", b)
+
+	if aMajor == "" || bMajor == "" {
+		return 0, false
+	}
+
+	// Major, minor, and patch versions are compared numerically.
+	for _, pair := range [][2]string{{aMajor, bMajor}, {aMinor, bMinor}, {aPatch, bPatch}} {
+		if result := compareNumeric(pair[0], pair[1]); result != 0 {
+			return result, true
+		}
+	}
+
+	// A version without a pre-release has a higher precedence than a version
+	// with one.
+	switch {
+	case aPreRelease == bPreRelease:
+		return 0, true
+	case aPreRelease == "":
+		return 1, true
+	case bPreRelease == "":
+		return -1, true
+	}
+
+	aIdentifiers := strings.Split(aPreRelease, ".")
+	bIdentifiers := strings.Split(bPreRelease, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		if result := compareIdentifier(aIdentifiers[i], bIdentifiers[i]); result != 0 {
+			return result, true
+		}
+	}
+
+	// A larger set of pre-release identifiers has a higher precedence.
+	return compareInt(len(aIdentifiers), len(bIdentifiers)), true
+}
+
+// compareIdentifier compares two semver pre-release identifiers. Numeric
+// identifiers are compared numerically, and always have a lower precedence
+// than alphanumeric identifiers, which are compared lexically.
+func compareIdentifier(a, b string) int {
+	_, aErr := strconv.ParseUint(a, 10, 64)
+	_, bErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareNumeric(a, b)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// compareNumeric compares two strings of digits, without leading zeros,
+// numerically. Values of any length are supported.
+func compareNumeric(a, b string) int {
+	if result := compareInt(len(a), len(b)); result != 0 {
+		return result
+	}
+
+	return strings.Compare(a, b)
+}
+
+// compareInt compares two ints, returning -1, 0, or +1.
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompareSemver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		expected int
+		invalid  bool
+	}{
+		{a: "", b: "1.0.0", invalid: true},
+		{a: "latest", b: "1.0.0", invalid: true},
+		{a: "1.0.0", b: "1.0", invalid: true},
+		{a: "1.0.0", b: "1.0.0", expected: 0},
+		{a: "v1.0.0", b: "1.0.0", expected: 0},
+		{a: "1.0.0+build.1", b: "1.0.0+build.2", expected: 0},
+		{a: "1.0.0", b: "2.0.0", expected: -1},
+		{a: "2.0.0", b: "2.1.0", expected: -1},
+		{a: "2.1.0", b: "2.1.1", expected: -1},
+		{a: "1.10.0", b: "1.9.0", expected: 1},
+		{a: "1.0.0-alpha", b: "1.0.0", expected: -1},
+		// The example precedence list from https://semver.org/#spec-item-11.
+		{a: "1.0.0-alpha", b: "1.0.0-alpha.1", expected: -1},
+		{a: "1.0.0-alpha.1", b: "1.0.0-alpha.beta", expected: -1},
+		{a: "1.0.0-alpha.beta", b: "1.0.0-beta", expected: -1},
+		{a: "1.0.0-beta", b: "1.0.0-beta.2", expected: -1},
+		{a: "1.0.0-beta.2", b: "1.0.0-beta.11", expected: -1},
+		{a: "1.0.0-beta.11", b: "1.0.0-rc.1", expected: -1},
+		{a: "1.0.0-rc.1", b: "1.0.0", expected: -1},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			actual, ok := compareSemver(test.a, test.b)
+			switch {
+			case ok == test.invalid:
+				t.Fatalf("expected valid to be %v but got %v", !test.invalid, ok)
+			case actual != test.expected:
+				t.Fatalf("expected %d but got %d", test.expected, actual)
+			}
+
+			// Comparisons must be symmetric.
+			if reverse, _ := compareSemver(test.b, test.a); reverse != -actual {
+				t.Fatalf("expected %d but got %d", -actual, reverse)
+			}
+		})
+	}
+}
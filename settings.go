@@ -0,0 +1,71 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// Settings is how the application was compiled, as recorded by the Go
+// toolchain in the build information.
+type Settings struct {
+	// Compiler is the compiler toolchain used, such as gc or gccgo.
+	Compiler string `json:"compiler,omitempty"`
+
+	// Tags are the build tags given with -tags.
+	Tags []string `json:"tags,omitempty"`
+
+	// Ldflags are the flags given with -ldflags.
+	Ldflags string `json:"ldflags,omitempty"`
+
+	// Trimpath reports if file system paths were removed with -trimpath.
+	Trimpath bool `json:"trimpath,omitempty"`
+
+	// Race reports if the race detector was enabled with -race.
+	Race bool `json:"race,omitempty"`
+
+	// CGO reports if cgo was enabled, using CGO_ENABLED.
+	CGO bool `json:"cgo,omitempty"`
+
+	// GOARM is the ARM architecture version targeted, when building for arm.
+	GOARM string `json:"goarm,omitempty"`
+
+	// GOAMD64 is the x86-64 microarchitecture level targeted, such as v1 or
+	// v3, when building for amd64.
+	GOAMD64 string `json:"goamd64,omitempty"`
+}
+
+// BuildSettings returns how the application was compiled, such as whether it
+// is a race or cgo enabled build. The result is empty if the application was
+// built without module support.
+func BuildSettings() Settings {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Settings{}
+	}
+
+	return parseSettings(info)
+}
+
+// parseSettings returns the typed build settings from the given build
+// information.
+func parseSettings(info *debug.BuildInfo) Settings {
+	settings := Settings{
+		Compiler: setting(info, "-compiler"),
+		Ldflags:  setting(info, "-ldflags"),
+		Trimpath: setting(info, "-trimpath") == "true",
+		Race:     setting(info, "-race") == "true",
+		CGO:      setting(info, "CGO_ENABLED") == "1",
+		GOARM:    setting(info, "GOARM"),
+		GOAMD64:  setting(info, "GOAMD64"),
+	}
+
+	if tags := setting(info, "-tags"); tags != "" {
+		settings.Tags = strings.Split(tags, ",")
+	}
+
+	return settings
+}
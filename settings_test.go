@@ -0,0 +1,67 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+func TestParseSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		settings []debug.BuildSetting
+		expected Settings
+	}{
+		{},
+		{
+			settings: []debug.BuildSetting{
+				{Key: "-compiler", Value: "gc"},
+				{Key: "-tags", Value: "netgo,osusergo"},
+				{Key: "-ldflags", Value: "-X 'jdk.sh/meta.version=v1.2.3'"},
+				{Key: "-trimpath", Value: "true"},
+				{Key: "-race", Value: "true"},
+				{Key: "CGO_ENABLED", Value: "1"},
+				{Key: "GOARCH", Value: "amd64"},
+				{Key: "GOAMD64", Value: "v3"},
+			},
+			expected: Settings{
+				Compiler: "gc",
+				Tags:     []string{"netgo", "osusergo"},
+				Ldflags:  "-X 'jdk.sh/meta.version=v1.2.3'",
+				Trimpath: true,
+				Race:     true,
+				CGO:      true,
+				GOAMD64:  "v3",
+			},
+		},
+		{
+			settings: []debug.BuildSetting{
+				{Key: "CGO_ENABLED", Value: "0"},
+				{Key: "GOARCH", Value: "arm"},
+				{Key: "GOARM", Value: "7"},
+			},
+			expected: Settings{
+				GOARM: "7",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		actual := parseSettings(&debug.BuildInfo{Settings: test.settings})
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Fatalf("expected %+v but got %+v", test.expected, actual)
+		}
+	}
+}
+
+func TestBuildSettings(t *testing.T) {
+	t.Parallel()
+
+	// Test binaries are always built with the gc compiler.
+	equalString(t, "gc", BuildSettings().Compiler)
+}
@@ -0,0 +1,123 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	u "net/url"
+	"runtime/debug"
+)
+
+// Snapshot is a snapshot of the application metadata, and is also the data that
+// templates are executed against. It contains the value of every metadata
+// variable, along with the Arch, Go, and OS of the running application. Values
+// derived from these, such as CommitURL or BuildSettings, are not included.
+type Snapshot struct {
+	Arch        string `json:"arch,omitempty"`
+	Author      string `json:"author,omitempty"`
+	AuthorEmail string `json:"author_email,omitempty"`
+	AuthorURL   string `json:"author_url,omitempty"`
+	Copyright   string `json:"copyright,omitempty"`
+	Date        string `json:"date,omitempty"`
+	Description string `json:"description,omitempty"`
+	Development bool   `json:"development,omitempty"`
+	Docs        string `json:"docs,omitempty"`
+	Go          string `json:"go,omitempty"`
+	License     string `json:"license,omitempty"`
+	LicenseURL  string `json:"license_url,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Note        string `json:"note,omitempty"`
+	OS          string `json:"os,omitempty"`
+	SHA         string `json:"sha,omitempty"`
+	ShortSHA    string `json:"short_sha,omitempty"`
+	Source      string `json:"src,omitempty"`
+	Title       string `json:"title,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Semver      Semver `json:"semver"`
+
+	// Sources is where the value of each variable came from, keyed by
+	// variable name. Variables that were not set are omitted.
+	Sources map[string]string `json:"sources,omitempty"`
+}
+
+// Semver is the semver portion of a Snapshot.
+type Semver struct {
+	Major      string `json:"major,omitempty"`
+	Minor      string `json:"minor,omitempty"`
+	Patch      string `json:"patch,omitempty"`
+	PreRelease string `json:"pre_release,omitempty"`
+	Build      string `json:"build,omitempty"`
+}
+
+// Data returns a snapshot of the application metadata, suitable for executing
+// templates returned by Template.
+func Data() Snapshot {
+	snapshot := global.snapshot()
+	snapshot.Arch, snapshot.Go, snapshot.OS = Arch(), Go(), OS()
+
+	return snapshot
+}
+
+// Parse validates and converts the given raw values, keyed by variable name,
+// in the same manner as is done when the application starts. The result
+// contains the values that each public function would return, had the
+// application been built with the given values. The Arch, Go, OS, and Sources
+// fields are left empty, since they describe the running application.
+//
+// An error is returned for any malformed value, where the application would
+// otherwise panic.
+func Parse(values map[string]string) (snapshot Snapshot, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	snapshot = newMetadata(values, nil).snapshot()
+	snapshot.Sources = nil
+
+	return snapshot, nil
+}
+
+// ParseWithBuildInfo is like Parse, but also falls back to the values inferred
+// from the given build information, for variables that were not set, in the
+// same manner as is done when the application starts. The Sources field is
+// populated, since it describes the given values. The build information may be
+// nil.
+func ParseWithBuildInfo(values map[string]string, info *debug.BuildInfo) (snapshot Snapshot, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	raw := make(map[string]string, len(values))
+	sources := make(map[string]string)
+
+	for name, value := range values {
+		raw[name] = value
+	}
+
+	if info != nil {
+		for name, value := range inferValues(info) {
+			if unreserved(raw[name]) == "" {
+				raw[name], sources[name] = value, SourceBuildInfo
+			}
+		}
+	}
+
+	return newMetadata(raw, sources).snapshot(), nil
+}
+
+// urlString returns the string form of the given URL, or an empty string if
+// it is nil.
+func urlString(url *u.URL) string {
+	if url == nil {
+		return ""
+	}
+
+	return url.String()
+}
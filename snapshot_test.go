@@ -0,0 +1,137 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values   map[string]string
+		expected Snapshot
+		fails    bool
+	}{
+		{
+			// No values at all.
+		},
+		{
+			values: map[string]string{
+				"jdk.sh/meta.author":  "Jane Doe <jdoe@example.com>",
+				"jdk.sh/meta.date":    "Fri, 23 Aug 2019 11:00:00 -0700",
+				"jdk.sh/meta.dev":     "true",
+				"jdk.sh/meta.name":    "demo-app",
+				"jdk.sh/meta.sha":     "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+				"jdk.sh/meta.src":     "https://example.com/demo.git",
+				"jdk.sh/meta.version": "v1.2.3-rc.456",
+			},
+			expected: Snapshot{
+				Author:      "Jane Doe",
+				AuthorEmail: "jdoe@example.com",
+				Date:        "2019-08-23T18:00:00Z",
+				Development: true,
+				Name:        "demo-app",
+				SHA:         "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+				ShortSHA:    "bb2fecb",
+				Source:      "https://example.com/demo.git",
+				Version:     "v1.2.3-rc.456",
+				Semver: Semver{
+					Major:      "1",
+					Minor:      "2",
+					Patch:      "3",
+					PreRelease: "rc.456",
+				},
+			},
+		},
+		{
+			values: map[string]string{
+				"jdk.sh/meta.src": "example.com/demo.git",
+			},
+			fails: true,
+		},
+		{
+			values: map[string]string{
+				"jdk.sh/meta.sha": "HEAD",
+			},
+			fails: true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Parse(test.values)
+
+			switch {
+			case err != nil && !test.fails:
+				t.Fatalf("expected success but got %v", err)
+			case err == nil && test.fails:
+				t.Fatal("expected failure but got success")
+			case !reflect.DeepEqual(test.expected, actual):
+				t.Fatalf("expected %+v but got %+v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseWithBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	info := &debug.BuildInfo{
+		Main: debug.Module{Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123456789abcdef01234567"},
+			{Key: "vcs.time", Value: "2019-08-23T18:00:00Z"},
+		},
+	}
+
+	actual, err := ParseWithBuildInfo(map[string]string{
+		"jdk.sh/meta.name": "demo-app",
+		"jdk.sh/meta.sha":  "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+		// Placeholder values are treated as if they were not set.
+		"jdk.sh/meta.version": Reserve(32),
+	}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equalString(t, "demo-app", actual.Name)
+	equalString(t, "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6", actual.SHA)
+	equalString(t, "v1.2.3", actual.Version)
+	equalString(t, "2019-08-23T18:00:00Z", actual.Date)
+
+	expected := map[string]string{
+		"jdk.sh/meta.date":    SourceBuildInfo,
+		"jdk.sh/meta.name":    SourceLdflags,
+		"jdk.sh/meta.sha":     SourceLdflags,
+		"jdk.sh/meta.version": SourceBuildInfo,
+	}
+
+	if !reflect.DeepEqual(expected, actual.Sources) {
+		t.Fatalf("expected %v but got %v", expected, actual.Sources)
+	}
+
+	// Without build information, the result is the same as Parse, other than
+	// the sources.
+	actual, err = ParseWithBuildInfo(map[string]string{"jdk.sh/meta.name": "demo-app"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equalString(t, "", actual.Version)
+	equalString(t, SourceLdflags, actual.Sources["jdk.sh/meta.name"])
+
+	if _, err := ParseWithBuildInfo(map[string]string{"jdk.sh/meta.sha": "HEAD"}, info); err == nil {
+		t.Fatal("expected failure but got success")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"strings"
+	"text/template"
+)
+
+// Template returns a new, empty, template with the metadata helper functions
+// already defined. The following functions are available:
+//
+//   date "2006-01-02"  the build date, formatted using the given layout
+//   short .SHA         the given git SHA, shortened to 7 characters
+//   semver             the semver version components, e.g. (semver).Major
+//
+// Templates should be executed with the value returned by Data. The date and
+// semver functions always describe the running application, even when a
+// template is executed with some other Snapshot, such as one returned by
+// Parse. The .Date and .Semver fields describe the Snapshot itself.
+func Template() *template.Template {
+	return template.New("meta").Funcs(template.FuncMap{
+		"date":   DateFormat,
+		"semver": func() Semver { return Data().Semver },
+		"short":  short,
+	})
+}
+
+// Render executes the given template text against the application metadata.
+// Every field of Snapshot is available as a template field, for example
+// {{.Name}}, {{.Version}}, or {{.Semver.Major}}. See Template for
+// the list of available helper functions.
+//
+// Example:
+//   meta.Render("{{.Name}}/{{.Version}} ({{.OS}}; {{.Arch}}) built {{date \"2006-01-02\"}}")
+func Render(text string) (string, error) {
+	tmpl, err := Template().Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, Data()); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// short shortens the given git SHA to 7 characters.
+func short(sha string) string {
+	const shortSHALength = 7
+	if len(sha) <= shortSHALength {
+		return sha
+	}
+
+	return sha[:shortSHALength]
+}
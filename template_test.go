@@ -0,0 +1,100 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected string
+		fails    bool
+	}{
+		{
+			input:    "",
+			expected: "",
+		},
+		{
+			input:    "{{.OS}}/{{.Arch}} {{.Go}}",
+			expected: runtime.GOOS + "/" + runtime.GOARCH + " " + runtime.Version(),
+		},
+		{
+			input:    `{{.Name}}{{.Version}}{{.Semver.Major}}{{(semver).Minor}}{{date "2006-01-02"}}`,
+			expected: "",
+		},
+		{
+			input:    `{{short "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6"}}`,
+			expected: "bb2fecb",
+		},
+		{
+			input: "{{.Missing}}",
+			fails: true,
+		},
+		{
+			input: "{{.Name",
+			fails: true,
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Render(test.input)
+
+			switch {
+			case err != nil && !test.fails:
+				t.Fatalf("expected success but got %v", err)
+			case err == nil && test.fails:
+				t.Fatal("expected failure but got success")
+			}
+
+			equalString(t, test.expected, actual)
+		})
+	}
+}
+
+func TestTemplateSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshot, err := Parse(map[string]string{
+		"jdk.sh/meta.date":    "2019-08-23T18:00:00Z",
+		"jdk.sh/meta.version": "v1.2.3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := Template().Parse(`{{.Date}} {{.Semver.Major}} [{{date "2006-01-02"}}{{(semver).Major}}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	// The helper functions describe the test binary, which was built without
+	// any values.
+	equalString(t, "2019-08-23T18:00:00Z 1 []", b.String())
+}
+
+func TestShort(t *testing.T) {
+	t.Parallel()
+
+	equalString(t, "", short(""))
+	equalString(t, "bb2fe", short("bb2fe"))
+	equalString(t, "bb2fecb", short("bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6"))
+}
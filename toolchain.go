@@ -0,0 +1,208 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GoVersion is a parsed Go toolchain version, as returned by runtime.Version.
+// See https://go.dev/doc/toolchain#version.
+type GoVersion struct {
+	// Raw is the unparsed version.
+	Raw string `json:"raw"`
+
+	// Major is the major version, which is always 1 for released toolchains.
+	Major int `json:"major"`
+
+	// Minor is the minor version, which identifies the Go release.
+	Minor int `json:"minor"`
+
+	// Patch is the patch version.
+	Patch int `json:"patch"`
+
+	// PreRelease is the pre-release version, such as rc1 or beta2.
+	PreRelease string `json:"pre_release,omitempty"`
+
+	// Devel reports if the toolchain was built from an unreleased development
+	// tree. The version numbers are zero if the base version is unknown.
+	Devel bool `json:"devel,omitempty"`
+
+	// Experiments are the GOEXPERIMENT values that the toolchain was built
+	// with, such as boringcrypto.
+	Experiments []string `json:"experiments,omitempty"`
+}
+
+// goVersionRegex matches the version portion of a Go toolchain version, such
+// as go1.21.3, go1.20, or go1.22rc1.
+var goVersionRegex = regexp.MustCompile(`^go(\d+)\.(\d+)(?:\.(\d+))?((?:rc|beta)\d+)?`)
+
+// goReleases is the release date of every Go release, keyed by minor version.
+// See https://go.dev/doc/devel/release.
+var goReleases = map[int]time.Time{
+	16: time.Date(2021, time.February, 16, 0, 0, 0, 0, time.UTC), // nolint:gomnd
+	17: time.Date(2021, time.August, 16, 0, 0, 0, 0, time.UTC),   // nolint:gomnd
+	18: time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC),    // nolint:gomnd
+	19: time.Date(2022, time.August, 2, 0, 0, 0, 0, time.UTC),    // nolint:gomnd
+	20: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),  // nolint:gomnd
+	21: time.Date(2023, time.August, 8, 0, 0, 0, 0, time.UTC),    // nolint:gomnd
+	22: time.Date(2024, time.February, 6, 0, 0, 0, 0, time.UTC),  // nolint:gomnd
+	23: time.Date(2024, time.August, 13, 0, 0, 0, 0, time.UTC),   // nolint:gomnd
+	24: time.Date(2025, time.February, 11, 0, 0, 0, 0, time.UTC), // nolint:gomnd
+	25: time.Date(2025, time.August, 12, 0, 0, 0, 0, time.UTC),   // nolint:gomnd
+}
+
+// Toolchain returns the parsed version of the Go toolchain that the
+// application was built with.
+func Toolchain() GoVersion {
+	return ParseGoVersion(runtime.Version())
+}
+
+// ParseGoVersion parses the given Go toolchain version, such as go1.21.3,
+// go1.22rc1, go1.21.3 X:boringcrypto, or devel go1.23-abc123 Tue Mar 5. Any
+// part of the version that can not be parsed is left empty.
+func ParseGoVersion(raw string) GoVersion {
+	version := GoVersion{Raw: raw}
+	rest := raw
+
+	// Experiments are listed after the version, such as X:loopvar,rangefunc.
+	if index := strings.Index(rest, " X:"); index >= 0 {
+		version.Experiments = strings.Split(rest[index+len(" X:"):], ",")
+		rest = rest[:index]
+	}
+
+	if strings.HasPrefix(rest, "devel ") {
+		version.Devel = true
+		rest = strings.TrimPrefix(rest, "devel ")
+	}
+
+	matches := goVersionRegex.FindStringSubmatch(rest)
+	if matches == nil {
+		return version
+	}
+
+	version.Major, _ = strconv.Atoi(matches[1])
+	version.Minor, _ = strconv.Atoi(matches[2])
+	version.Patch, _ = strconv.Atoi(matches[3])
+	version.PreRelease = matches[4]
+
+	return version
+}
+
+// String returns the raw version.
+func (v GoVersion) String() string {
+	return v.Raw
+}
+
+// Compare returns -1, 0, or 1 if the version is older than, the same as, or
+// newer than the given version. For the same release, development versions
+// are older than beta versions, which are older than release candidates,
+// which are older than the release itself.
+func (v GoVersion) Compare(other GoVersion) int {
+	if result := compareInts(
+		v.Major, other.Major,
+		v.Minor, other.Minor,
+		v.Patch, other.Patch,
+		v.stage(), other.stage(),
+	); result != 0 {
+		return result
+	}
+
+	return compareNumeric(
+		strings.TrimLeft(v.PreRelease, "betarc"),
+		strings.TrimLeft(other.PreRelease, "betarc"),
+	)
+}
+
+// AtLeast reports if the version is the same as, or newer than, the given
+// release, such as 1 and 21 for Go 1.21.
+func (v GoVersion) AtLeast(major, minor int) bool {
+	return v.Compare(GoVersion{Major: major, Minor: minor}) >= 0
+}
+
+// Released returns the date that the release, such as Go 1.21, was made
+// available. Release dates for versions newer than those known to this package
+// are estimated from the six month release cycle.
+func (v GoVersion) Released() (time.Time, bool) {
+	if v.Major != 1 || v.Minor == 0 {
+		return time.Time{}, false
+	}
+
+	return goRelease(v.Minor)
+}
+
+// EndOfLife returns the date that the release stopped receiving security
+// fixes. Each release is supported until there are two newer releases. The
+// date is estimated if it has not yet happened.
+func (v GoVersion) EndOfLife() (time.Time, bool) {
+	if v.Major != 1 || v.Minor == 0 {
+		return time.Time{}, false
+	}
+
+	return goRelease(v.Minor + 2) // nolint:gomnd
+}
+
+// Supported reports if the release was still receiving security fixes at the
+// given time. A version that can not be parsed, or is older than those known
+// to this package, is considered to be unsupported.
+func (v GoVersion) Supported(at time.Time) bool {
+	eol, ok := v.EndOfLife()
+
+	return ok && at.Before(eol)
+}
+
+// stage returns the ordering of the pre-release stage of the version.
+func (v GoVersion) stage() int {
+	switch {
+	case v.Devel:
+		return 0
+	case strings.HasPrefix(v.PreRelease, "beta"):
+		return 1
+	case strings.HasPrefix(v.PreRelease, "rc"):
+		return 2 // nolint:gomnd
+	default:
+		return 3 // nolint:gomnd
+	}
+}
+
+// goRelease returns the release date for the given minor version, estimating
+// the date if the release is newer than those known to this package.
+func goRelease(minor int) (time.Time, bool) {
+	if date, found := goReleases[minor]; found {
+		return date, true
+	}
+
+	latest := 0
+	for known := range goReleases {
+		if known > latest {
+			latest = known
+		}
+	}
+
+	if minor < latest {
+		return time.Time{}, false
+	}
+
+	// Releases are made every six months, in February and August.
+	const monthsPerRelease = 6
+
+	return goReleases[latest].AddDate(0, monthsPerRelease*(minor-latest), 0), true
+}
+
+// compareInts compares each given pair of values in turn, and returns the
+// first non-zero result.
+func compareInts(pairs ...int) int {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if result := compareInt(pairs[i], pairs[i+1]); result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,136 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw      string
+		expected GoVersion
+	}{
+		{
+			raw:      "",
+			expected: GoVersion{},
+		},
+		{
+			raw:      "go1.20",
+			expected: GoVersion{Major: 1, Minor: 20},
+		},
+		{
+			raw:      "go1.21.3",
+			expected: GoVersion{Major: 1, Minor: 21, Patch: 3},
+		},
+		{
+			raw:      "go1.22rc1",
+			expected: GoVersion{Major: 1, Minor: 22, PreRelease: "rc1"},
+		},
+		{
+			raw:      "go1.21.3 X:boringcrypto",
+			expected: GoVersion{Major: 1, Minor: 21, Patch: 3, Experiments: []string{"boringcrypto"}},
+		},
+		{
+			raw:      "go1.22.0 X:loopvar,rangefunc",
+			expected: GoVersion{Major: 1, Minor: 22, Experiments: []string{"loopvar", "rangefunc"}},
+		},
+		{
+			raw:      "devel go1.23-ba8e9e14 Mon Mar 4 17:49:43 2024 +0000",
+			expected: GoVersion{Major: 1, Minor: 23, Devel: true},
+		},
+		{
+			raw:      "devel +b7a03218b1 Tue Jan 12 10:16:09 2021 +0000",
+			expected: GoVersion{Devel: true},
+		},
+	}
+
+	for _, test := range tests {
+		test.expected.Raw = test.raw
+
+		actual := ParseGoVersion(test.raw)
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Fatalf("expected %q to parse as %+v but got %+v", test.raw, test.expected, actual)
+		}
+	}
+}
+
+func TestToolchain(t *testing.T) {
+	t.Parallel()
+
+	version := Toolchain()
+	equalString(t, runtime.Version(), version.String())
+
+	if !version.Devel && !version.AtLeast(1, 18) {
+		t.Fatalf("expected toolchain %s to be at least go1.18", version)
+	}
+}
+
+func TestGoVersionCompare(t *testing.T) {
+	t.Parallel()
+
+	// Versions are listed from oldest to newest.
+	versions := []string{
+		"go1.20",
+		"go1.20.1",
+		"devel go1.21-abc123 Mon Mar 4 17:49:43 2024 +0000",
+		"go1.21beta1",
+		"go1.21rc2",
+		"go1.21rc10",
+		"go1.21.0",
+		"go1.21.3 X:boringcrypto",
+		"go1.22.0",
+	}
+
+	for i, a := range versions {
+		for j, b := range versions {
+			expected := compareInt(i, j)
+
+			if actual := ParseGoVersion(a).Compare(ParseGoVersion(b)); actual != expected {
+				t.Fatalf("expected comparing %q to %q to be %d but got %d", a, b, expected, actual)
+			}
+		}
+	}
+}
+
+func TestGoVersionSupported(t *testing.T) {
+	t.Parallel()
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		raw       string
+		at        time.Time
+		supported bool
+	}{
+		{raw: "go1.21.3", at: date(2023, time.September, 1), supported: true},
+		{raw: "go1.21.3", at: date(2024, time.August, 12), supported: true},
+		{raw: "go1.21.3", at: date(2024, time.August, 13), supported: false},
+		{raw: "go1.24.0", at: date(2025, time.December, 1), supported: true},
+		// The release of go1.27 is estimated.
+		{raw: "go1.25.0", at: date(2026, time.August, 1), supported: true},
+		{raw: "go1.25.0", at: date(2026, time.September, 1), supported: false},
+		{raw: "go1.12", at: date(2019, time.March, 1), supported: false},
+		{raw: "devel +b7a03218b1", at: date(2021, time.January, 1), supported: false},
+	}
+
+	for _, test := range tests {
+		if actual := ParseGoVersion(test.raw).Supported(test.at); actual != test.supported {
+			t.Fatalf("expected %q supported at %s to be %t", test.raw, test.at, test.supported)
+		}
+	}
+
+	released, ok := ParseGoVersion("go1.21.3").Released()
+	if !ok || !released.Equal(date(2023, time.August, 8)) {
+		t.Fatalf("unexpected release date %s", released)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"net/http"
+)
+
+// Default header names that are set by Transport.
+const (
+	HeaderClientName     = "X-Client-Name"
+	HeaderClientRevision = "X-Client-Revision"
+	HeaderClientVersion  = "X-Client-Version"
+)
+
+// transport is an http.RoundTripper that sets identifying headers on every
+// request before passing it on.
+type transport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+// Transport returns an http.RoundTripper that identifies the application on
+// every outgoing request, by setting the following headers:
+//
+//   User-Agent         the value of UserAgent
+//   X-Client-Name      the value of Name
+//   X-Client-Version   the value of Version
+//   X-Client-Revision  the value of SHA
+//
+// Headers with an empty value are not set. Headers that were already
+// explicitly set on a request are never replaced. Requests are then sent using
+// the given base http.RoundTripper, or http.DefaultTransport if nil.
+//
+// The set of headers can be customized with TransportHeaders.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return TransportHeaders(base, http.Header{
+		"User-Agent":         {UserAgent()},
+		HeaderClientName:     {Name()},
+		HeaderClientVersion:  {Version()},
+		HeaderClientRevision: {SHA()},
+	})
+}
+
+// TransportHeaders returns an http.RoundTripper that sets the given headers on
+// every outgoing request. Headers with an empty value are not set. Headers that
+// were already explicitly set on a request are never replaced. Requests are
+// then sent using the given base http.RoundTripper, or http.DefaultTransport
+// if nil.
+func TransportHeaders(base http.RoundTripper, headers http.Header) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// Take a copy of the headers, as they might be modified by the caller.
+	cloned := make(http.Header, len(headers))
+
+	for key, values := range headers {
+		for _, value := range values {
+			if value != "" {
+				cloned.Add(key, value)
+			}
+		}
+	}
+
+	return &transport{
+		base:    base,
+		headers: cloned,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var cloned *http.Request
+
+	for key, values := range t.headers {
+		if _, found := req.Header[key]; found {
+			continue
+		}
+
+		// A RoundTripper must not modify the given request, so only clone it
+		// once it's known that a header needs to be set.
+		if cloned == nil {
+			cloned = req.Clone(req.Context())
+			if cloned.Header == nil {
+				cloned.Header = make(http.Header)
+			}
+		}
+
+		// Each request gets its own copy of the values, so that a later
+		// RoundTripper modifying them can not affect other requests.
+		cloned.Header[key] = append([]string(nil), values...)
+	}
+
+	if cloned == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	return t.base.RoundTrip(cloned)
+}
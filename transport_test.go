@@ -0,0 +1,155 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		headers  http.Header
+		request  http.Header
+		expected http.Header
+	}{
+		{
+			// Every header is set.
+			headers: http.Header{
+				"User-Agent":       {"demo-app/v1.2.3"},
+				"X-Client-Name":    {"demo-app"},
+				"x-client-version": {"v1.2.3"},
+			},
+			expected: http.Header{
+				"User-Agent":       {"demo-app/v1.2.3"},
+				"X-Client-Name":    {"demo-app"},
+				"X-Client-Version": {"v1.2.3"},
+			},
+		},
+		{
+			// Empty headers are not set.
+			headers: http.Header{
+				"User-Agent":       {"demo-app"},
+				"X-Client-Version": {""},
+			},
+			expected: http.Header{
+				"User-Agent":       {"demo-app"},
+				"X-Client-Version": nil,
+			},
+		},
+		{
+			// Explicit headers are not replaced.
+			headers: http.Header{
+				"User-Agent":    {"demo-app/v1.2.3"},
+				"X-Client-Name": {"demo-app"},
+			},
+			request: http.Header{
+				"User-Agent": {"custom/v4.5.6"},
+			},
+			expected: http.Header{
+				"User-Agent":    {"custom/v4.5.6"},
+				"X-Client-Name": {"demo-app"},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var received http.Header
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil) // nolint:noctx
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header = test.request.Clone()
+
+			client := http.Client{Transport: TransportHeaders(nil, test.headers)}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			for key, values := range test.expected {
+				equalString(t, strings.Join(values, ","), strings.Join(received.Values(key), ","))
+			}
+
+			// The original request must not have been modified.
+			if len(req.Header) != len(test.request) {
+				t.Fatalf("expected %v but got %v", test.request, req.Header)
+			}
+		})
+	}
+}
+
+func TestTransportDefaults(t *testing.T) {
+	t.Parallel()
+
+	tr, ok := Transport(nil).(*transport)
+	if !ok {
+		t.Fatal("expected a *transport")
+	}
+
+	equalString(t, UserAgent(), tr.headers.Get("User-Agent"))
+
+	// No name, version, or SHA is set while testing.
+	for _, key := range []string{HeaderClientName, HeaderClientRevision, HeaderClientVersion} {
+		if _, found := tr.headers[key]; found {
+			t.Fatalf("expected header %s to not be set", key)
+		}
+	}
+}
+
+// mutatingTransport records, then modifies, the headers of every request in
+// place.
+type mutatingTransport struct {
+	seen []string
+}
+
+func (m *mutatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.seen = append(m.seen, req.Header.Get("X-Test"))
+	req.Header["X-Test"][0] = "modified"
+
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestTransportCopiesHeaders(t *testing.T) {
+	t.Parallel()
+
+	base := &mutatingTransport{}
+	client := http.Client{Transport: TransportHeaders(base, http.Header{"X-Test": {"original"}})}
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, "http://example.com", nil) // nolint:noctx
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	// Modifying the headers of one request must not affect later requests.
+	equalString(t, "original,original", strings.Join(base.seen, ","))
+}
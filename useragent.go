@@ -0,0 +1,88 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UserAgent is a User-Agent string identifying the application, for use with
+// HTTP clients. It follows the product and comment syntax described in RFC
+// 9110, section 10.1.5, and takes the form:
+//
+//   name/version (os; arch[; comment...]) go/version
+//
+// The application binary name is used when no name was given, and the version
+// is omitted when no version was given. Any characters that are not valid in
+// a product token are replaced with a "-". Additional comments, such as the
+// value of ShortSHA, can be optionally given. Empty comments are skipped.
+//
+// Example:
+//   demo-app/v1.2.3 (linux; amd64; bb2fecb) go/1.17.1
+func UserAgent(comments ...string) string {
+	appName := Name()
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+
+	parts := []string{OS(), Arch()}
+
+	for _, comment := range comments {
+		if comment = sanitizeComment(comment); comment != "" {
+			parts = append(parts, comment)
+		}
+	}
+
+	return product(appName, Version()) +
+		" (" + strings.Join(parts, "; ") + ") " +
+		product("go", strings.TrimPrefix(Go(), "go"))
+}
+
+// product formats the given name and version as a product identifier.
+func product(name, version string) string {
+	if version == "" {
+		return sanitizeToken(name)
+	}
+
+	return sanitizeToken(name) + "/" + sanitizeToken(version)
+}
+
+// sanitizeToken replaces all characters in the given value that are not a
+// valid tchar with a "-".
+// See https://www.rfc-editor.org/rfc/rfc9110#section-5.6.2.
+func sanitizeToken(raw string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case '0' <= r && r <= '9':
+		case 'a' <= r && r <= 'z':
+		case 'A' <= r && r <= 'Z':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return '-'
+		}
+
+		return r
+	}, raw)
+}
+
+// sanitizeComment removes all characters in the given value that are not a
+// valid ctext, which notably excludes parenthesis and backslashes.
+// See https://www.rfc-editor.org/rfc/rfc9110#section-5.6.5.
+func sanitizeComment(raw string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == ' ':
+		case r == '(' || r == ')' || r == '\\':
+			return -1
+		case 0x21 <= r && r <= 0x7e:
+		default:
+			return -1
+		}
+
+		return r
+	}, raw))
+}
@@ -0,0 +1,90 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		comments []string
+		expected string
+	}{
+		{
+			expected: "meta.test (" + runtime.GOOS + "; " + runtime.GOARCH + ") go/",
+		},
+		{
+			comments: []string{"", "bb2fecb", "  "},
+			expected: "meta.test (" + runtime.GOOS + "; " + runtime.GOARCH + "; bb2fecb) go/",
+		},
+		{
+			comments: []string{"built (on) CI\\"},
+			expected: "meta.test (" + runtime.GOOS + "; " + runtime.GOARCH + "; built on CI) go/",
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			actual := UserAgent(test.comments...)
+			if !strings.HasPrefix(actual, test.expected) {
+				t.Fatalf("expected %q to be a prefix of %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSanitizeToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "",
+			expected: "",
+		},
+		{
+			input:    "demo-app",
+			expected: "demo-app",
+		},
+		{
+			input:    "v1.2.3-rc.456+build.789",
+			expected: "v1.2.3-rc.456+build.789",
+		},
+		{
+			input:    "Demo App/2",
+			expected: "Demo-App-2",
+		},
+		{
+			input:    "devel go1.18-abc Mon Jan 2",
+			expected: "devel-go1.18-abc-Mon-Jan-2",
+		},
+		{
+			input:    "démo(app)",
+			expected: "d-mo-app-",
+		},
+	}
+
+	for i, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			equalString(t, test.expected, sanitizeToken(test.input))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	"fmt"
+	"strings"
+)
+
+// vanities maps vanity import path prefixes to repository URL prefixes.
+var vanities = make(map[string]string)
+
+// RegisterVanity adds a mapping from a vanity import path prefix, such as
+// jdk.sh, to the URL prefix of the repositories that it serves, such as
+// https://github.com/joshdk. The first path element after the prefix is
+// appended to the URL, so that the module jdk.sh/meta is resolved to
+// https://github.com/joshdk/meta. If the prefix is the complete module path,
+// the URL is used as is.
+//
+// The mapping is used to derive the source and homepage URLs when they are not
+// otherwise set, and so is intended to be called from an init function.
+//
+// RegisterVanity panics if given a malformed URL.
+func RegisterVanity(prefix, url string) {
+	if _, err := lookupField("jdk.sh/meta.url").tryParse(url); err != nil || url == "" {
+		panic(fmt.Errorf("malformed vanity url %s", url))
+	}
+
+	vanities[strings.TrimSuffix(prefix, "/")] = strings.TrimSuffix(url, "/")
+
+	if buildInfo != nil {
+		inferFallbacks(buildInfo)
+	}
+
+	parse()
+}
+
+// vanitySource returns the URL of the repository serving the given vanity
+// module path, using the longest matching prefix, or an empty string if there
+// is no matching prefix.
+func vanitySource(path string) string {
+	var match string
+
+	for prefix := range vanities {
+		if (path == prefix || strings.HasPrefix(path, prefix+"/")) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+
+	if match == "" {
+		return ""
+	}
+
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, match), "/")
+	if rest == "" {
+		return vanities[match]
+	}
+
+	return fmt.Sprintf("%s/%s", vanities[match], strings.Split(rest, "/")[0])
+}
@@ -0,0 +1,89 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	u "net/url"
+	"strings"
+)
+
+// expandVersioned replaces the version placeholders in the given value, using
+// the version and SHA from the given raw values, keyed by variable name. The
+// supported placeholders are {version}, {major}, {minor}, {patch}, {sha}, and
+// {short_sha}. If the value of any placeholder in use is not set, such as when
+// using go run, an empty string is returned, so that the value is treated as
+// if it was not set rather than as a URL containing a literal placeholder.
+func expandVersioned(value string, raw map[string]string) string {
+	if !strings.Contains(value, "{") {
+		return value
+	}
+
+	version := unreserved(raw["jdk.sh/meta.version"])
+	sha := unreserved(raw["jdk.sh/meta.sha"])
+
+	// A malformed version is reported when the version itself is parsed.
+	var semver Semver
+	if parsed, err := lookupField("jdk.sh/meta.version").tryParse(version); err == nil {
+		semver = parsed.(Semver) // nolint:forcetypeassert
+	}
+
+	replacements := map[string]string{
+		"{version}":   version,
+		"{major}":     semver.Major,
+		"{minor}":     semver.Minor,
+		"{patch}":     semver.Patch,
+		"{sha}":       sha,
+		"{short_sha}": short(sha),
+	}
+
+	pairs := make([]string, 0, len(replacements)*2) // nolint:gomnd
+
+	for placeholder, replacement := range replacements {
+		if !strings.Contains(value, placeholder) {
+			continue
+		}
+
+		if replacement == "" {
+			return ""
+		}
+
+		pairs = append(pairs, placeholder, replacement)
+	}
+
+	return strings.NewReplacer(pairs...).Replace(value)
+}
+
+// DocsURLFor returns the URL of the given page of the application
+// documentation, such as cli/install.html#linux, relative to the Docs URL. The
+// page is joined onto the Docs URL even if it does not end with a slash, so
+// that the versioned base https://example.com/docs/{version} becomes
+// https://example.com/docs/v1.2.3/cli/install.html#linux. Returns nil if the
+// Docs URL is not set, or the page is malformed.
+func DocsURLFor(page string) *u.URL {
+	return joinURL(Docs(), page)
+}
+
+// joinURL returns the given page joined onto the path of the given base URL.
+func joinURL(base *u.URL, page string) *u.URL {
+	if base == nil {
+		return nil
+	}
+
+	ref, err := u.Parse(page)
+	if err != nil || ref.IsAbs() || ref.Host != "" {
+		return nil
+	}
+
+	result := *base
+	result.Path = strings.TrimSuffix(base.Path, "/") + "/" + strings.TrimPrefix(ref.Path, "/")
+	result.RawPath = ""
+	result.Fragment = ref.Fragment
+
+	if ref.RawQuery != "" {
+		result.RawQuery = ref.RawQuery
+	}
+
+	return &result
+}
@@ -0,0 +1,166 @@
+// Copyright Josh Komoroske. All rights reserved.
+// Use of this source code is governed by the MIT license,
+// a copy of which can be found in the LICENSE.txt file.
+
+package meta
+
+import (
+	u "net/url"
+	"testing"
+)
+
+func TestExpandVersioned(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]string{
+		"jdk.sh/meta.sha":     "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+		"jdk.sh/meta.version": "v1.2.3-rc.1",
+	}
+
+	tests := []struct {
+		value    string
+		raw      map[string]string
+		expected string
+	}{
+		{
+			value:    "https://example.com/docs/",
+			raw:      raw,
+			expected: "https://example.com/docs/",
+		},
+		{
+			value:    "https://example.com/docs/{version}/",
+			raw:      raw,
+			expected: "https://example.com/docs/v1.2.3-rc.1/",
+		},
+		{
+			value:    "https://example.com/docs/{major}.{minor}.{patch}/",
+			raw:      raw,
+			expected: "https://example.com/docs/1.2.3/",
+		},
+		{
+			value:    "https://example.com/blob/{sha}/LICENSE.txt#{short_sha}",
+			raw:      raw,
+			expected: "https://example.com/blob/bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6/LICENSE.txt#bb2fecb",
+		},
+		{
+			value:    "https://example.com/docs/{major}/{unknown}/",
+			raw:      raw,
+			expected: "https://example.com/docs/1/{unknown}/",
+		},
+		{
+			// The version is not semver, and so has no major version.
+			value:    "https://example.com/docs/{version}/{major}/",
+			raw:      map[string]string{"jdk.sh/meta.version": "latest"},
+			expected: "",
+		},
+		{
+			value:    "https://example.com/docs/{version}/",
+			raw:      map[string]string{"jdk.sh/meta.version": Reserve(32)},
+			expected: "",
+		},
+		{
+			value:    "https://example.com/docs/{version}/",
+			raw:      map[string]string{},
+			expected: "",
+		},
+		{
+			// Only the placeholders in use need to be set.
+			value:    "https://example.com/docs/{version}/",
+			raw:      map[string]string{"jdk.sh/meta.version": "v1.2.3"},
+			expected: "https://example.com/docs/v1.2.3/",
+		},
+	}
+
+	for _, test := range tests {
+		equalString(t, test.expected, expandVersioned(test.value, test.raw))
+	}
+}
+
+func TestParseUnexpanded(t *testing.T) {
+	t.Parallel()
+
+	// Without a version, as when using go run, the docs URL can not be
+	// expanded, and is treated as if it was not set.
+	snapshot, err := Parse(map[string]string{
+		"jdk.sh/meta.docs":        "https://example.com/docs/{version}/",
+		"jdk.sh/meta.license_url": "https://v{major}.example.com/LICENSE.txt",
+		"jdk.sh/meta.sha":         "bb2fecbb4a287ea4c1f9887ca86dd0eb7ff28ec6",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equalString(t, "", snapshot.Docs)
+	equalString(t, "", snapshot.LicenseURL)
+}
+
+func TestJoinURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		base     string
+		page     string
+		expected string
+	}{
+		{
+			base:     "https://example.com/docs/v1.2.3",
+			page:     "cli/install.html#linux",
+			expected: "https://example.com/docs/v1.2.3/cli/install.html#linux",
+		},
+		{
+			base:     "https://example.com/docs/v1.2.3/",
+			page:     "/cli/?tab=linux",
+			expected: "https://example.com/docs/v1.2.3/cli/?tab=linux",
+		},
+		{
+			base:     "https://example.com",
+			page:     "",
+			expected: "https://example.com/",
+		},
+		{
+			base: "https://example.com/docs/",
+			page: "https://example.org/cli",
+		},
+		{
+			base: "https://example.com/docs/",
+			page: "//example.org/cli",
+		},
+	}
+
+	for _, test := range tests {
+		base, err := u.Parse(test.base)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := joinURL(base, test.page)
+
+		switch {
+		case test.expected == "" && actual != nil:
+			t.Fatalf("expected no url for %q but got %s", test.page, actual)
+		case test.expected != "" && actual == nil:
+			t.Fatalf("expected url for %q but got none", test.page)
+		case actual != nil:
+			equalString(t, test.expected, actual.String())
+		}
+	}
+
+	// The test binary is built without a docs url.
+	if actual := DocsURLFor("cli"); actual != nil {
+		t.Fatalf("expected no url but got %s", actual)
+	}
+}
+
+// TestDocsURLFor modifies global state, and so must not run in parallel.
+func TestDocsURLFor(t *testing.T) { // nolint:paralleltest
+	restoreVariables(t)
+
+	docs = "https://example.com/docs/{major}.{minor}"
+	license_url = "https://example.com/blob/{version}/LICENSE.txt"
+	version = "v1.2.3"
+	parse()
+
+	equalString(t, "https://example.com/docs/1.2", Docs().String())
+	equalString(t, "https://example.com/docs/1.2/cli/install.html", DocsURLFor("cli/install.html").String())
+	equalString(t, "https://example.com/blob/v1.2.3/LICENSE.txt", LicenseURL().String())
+}